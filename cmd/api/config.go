@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	config "soat-fiap/configs"
+)
+
+const usoComandos = `uso: soat-fiap [flags] [comando]
+
+sem comando, sobe a API.
+
+comandos:
+  migrate <comando>   gerencia as migrações do banco (ver "soat-fiap migrate")
+  config print        mostra a configuração efetiva, com segredos mascarados
+
+use "soat-fiap -h" para listar as flags.`
+
+const usoConfig = `uso: soat-fiap config <comando>
+
+comandos:
+  print         mostra a configuração efetiva e a origem de cada valor`
+
+// executarConfig implementa o subcomando "config".
+func executarConfig(cfg *config.Config, args []string) {
+	if len(args) != 1 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, usoConfig)
+		os.Exit(2)
+	}
+
+	if err := cfg.Imprimir(os.Stdout); err != nil {
+		encerrarComErro("erro ao imprimir a configuração", err)
+	}
+}
+
+// sairComProblemasDeConfiguracao lista todos os problemas encontrados. Roda
+// antes de o logger ser configurado, então escreve texto simples em stderr.
+func sairComProblemasDeConfiguracao(err error) {
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, usoComandos)
+		os.Exit(0)
+	}
+
+	var problemas config.Problemas
+	if !errors.As(err, &problemas) {
+		// O pacote flag já escreveu o erro e o uso.
+		os.Exit(2)
+	}
+
+	fmt.Fprintln(os.Stderr, "configuração inválida:")
+	for _, problema := range problemas {
+		fmt.Fprintln(os.Stderr, "  -", problema)
+	}
+	os.Exit(2)
+}
@@ -2,21 +2,31 @@ package main
 
 import (
 	"context"
-	"log"
+	"database/sql"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	config "soat-fiap/configs"
+	"soat-fiap/docs"
 	"soat-fiap/internal/adapters/primary/handlers"
-	"soat-fiap/internal/adapters/secondary/repositories"
+	"soat-fiap/internal/adapters/primary/middleware"
+	"soat-fiap/internal/adapters/secondary/armazenamento"
+	"soat-fiap/internal/adapters/secondary/metricas"
+	"soat-fiap/internal/core/ports"
 	"soat-fiap/internal/core/services"
 	"soat-fiap/internal/routes"
-	mysql "soat-fiap/pkg/database"
+	"soat-fiap/pkg/database"
+	"soat-fiap/pkg/logger"
+	"soat-fiap/pkg/telemetria"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -36,53 +46,134 @@ const (
 // @license.name  MIT
 // @license.url   https://opensource.org/licenses/MIT
 
-// @host      localhost:8080
 // @BasePath  /api/v1
 func main() {
-	cfg := config.LoadConfig()
-
-	db, err := mysql.ConectarMySQL(
-		cfg.DBHost,
-		cfg.DBPort,
-		cfg.DBUser,
-		cfg.DBPassword,
-		cfg.DBName,
-	)
+	cfg, args, err := config.Carregar(os.Args[1:])
 	if err != nil {
-		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+		sairComProblemasDeConfiguracao(err)
 	}
-	defer db.Close()
+	configurarLog(cfg.LogLevel)
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			executarMigrate(cfg, args[1:])
+		case "config":
+			executarConfig(cfg, args[1:])
+		default:
+			fmt.Fprintf(os.Stderr, "comando desconhecido: %s\n\n%s\n", args[0], usoComandos)
+			os.Exit(2)
+		}
+		return
+	}
+
+	encerrarTelemetria, err := telemetria.Configurar(context.Background(), telemetria.Opcoes{
+		Habilitado: cfg.TracingEnable,
+		Servico:    "soat-fiap",
+		Versao:     AppVersion,
+		Endpoint:   cfg.TracingEndpoint,
+		Amostragem: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		encerrarComErro("erro ao configurar o rastreamento", err)
+	}
+
+	repos, err := abrirRepositorios(cfg)
+	if err != nil {
+		encerrarComErro("erro ao conectar ao banco de dados", err)
+	}
+	defer repos.fechar()
+
+	registroMetricas := metricas.NovoRegistro()
+	if repos.db != nil {
+		metricas.RegistrarPool(registroMetricas, repos.db, cfg.DBDriver)
+	}
+	metricas.RegistrarPedidosPorStatus(registroMetricas, repos.pedidos)
 
-	clienteRepository := repositories.NovoClienteRepository(db)
-	produtoRepository := repositories.NovoProdutoRepository(db)
-	pedidoRepository := repositories.NovoPedidoRepository(db)
+	arquivos, err := armazenamento.NovoArmazenamentoLocal(cfg.StorageDir, cfg.StoragePublicURL)
+	if err != nil {
+		encerrarComErro("erro ao preparar o armazenamento de arquivos", err)
+	}
 
-	clienteService := services.NovoClienteService(clienteRepository)
-	produtoService := services.NovoProdutoService(produtoRepository)
-	pedidoService := services.NovoPedidoService(pedidoRepository, produtoRepository)
+	clienteService := services.NovoClienteService(repos.clientes)
+	produtoService := services.NovoProdutoService(repos.produtos, repos.categorias, arquivos)
+	categoriaService := services.NovoCategoriaService(repos.categorias)
+	pedidoService := services.NovoPedidoService(repos.pedidos, repos.produtos, metricas.NovoMetricasPedidos(registroMetricas))
+
+	ctxPrecos, pararPrecos := context.WithCancel(context.Background())
+	defer pararPrecos()
+	if cfg.PriceApplyInterval > 0 {
+		go aplicarPrecosPeriodicamente(ctxPrecos, produtoService, cfg.PriceApplyInterval)
+	}
 
 	clienteHandler := handlers.NovoClienteHandler(clienteService)
-	produtoHandler := handlers.NovoProdutoHandler(produtoService)
+	produtoHandler := handlers.NovoProdutoHandler(produtoService, int64(cfg.ImageMaxSize))
+	categoriaHandler := handlers.NovoCategoriaHandler(categoriaService)
 	pedidoHandler := handlers.NovoPedidoHandler(pedidoService)
-	healthHandler := handlers.NovoHealthHandler(AppVersion)
+	healthHandler := handlers.NovoHealthHandler(AppVersion, repos.verificadores...)
 
 	router := mux.NewRouter()
-	routes.ConfigurarRotas(router, clienteHandler, produtoHandler, pedidoHandler, healthHandler)
+	router.Use(middleware.Rastreamento("soat-fiap"))
+	router.Use(middleware.Metricas(registroMetricas))
+	router.Use(middleware.Log(slog.Default()))
+	if cfg.IfMatchRequired {
+		router.Use(middleware.ExigirIfMatch)
+	}
+	if cfg.RequestValidation {
+		validacao, err := middleware.ValidacaoOpenAPI(docs.OpenAPI, cfg.RequestValidationStrict)
+		if err != nil {
+			encerrarComErro("erro ao carregar a especificação OpenAPI", err)
+		}
+		router.Use(validacao)
+	}
+	routes.ConfigurarRotas(router, clienteHandler, produtoHandler, categoriaHandler, pedidoHandler, healthHandler)
 
-	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
+	if cfg.SwaggerEnable {
+		documentacaoHandler, err := handlers.NovoDocumentacaoHandler(docs.OpenAPI, AppVersion)
+		if err != nil {
+			encerrarComErro("erro ao carregar a especificação OpenAPI", err)
+		}
+		router.HandleFunc("/openapi.json", documentacaoHandler.OpenAPI).Methods(http.MethodGet)
+		router.PathPrefix("/swagger/").Handler(httpSwagger.Handler(httpSwagger.URL("/openapi.json")))
+	}
+	if strings.HasPrefix(cfg.StoragePublicURL, "/") {
+		prefixo := strings.TrimSuffix(cfg.StoragePublicURL, "/")
+		router.PathPrefix(prefixo+"/").Handler(http.StripPrefix(prefixo, arquivos.Handler())).Methods(http.MethodGet, http.MethodHead)
+	}
+	router.Handle("/metrics", promhttp.HandlerFor(registroMetricas, promhttp.HandlerOpts{})).Methods(http.MethodGet)
+
+	// CORS e cabeçalhos de segurança ficam fora do router para valerem também
+	// nos preflights OPTIONS e nas respostas 404/405 do mux.
+	var handler http.Handler = router
+	handler = middleware.CabecalhosSeguranca(middleware.OpcoesSeguranca{
+		HSTS:                cfg.HSTSMaxAge,
+		HSTSSubdominios:     cfg.HSTSIncludeSubdomains,
+		CSP:                 cfg.CSP,
+		CSPDocumentacao:     cfg.CSPDocs,
+		PrefixoDocumentacao: "/swagger/",
+	})(handler)
+	handler = middleware.CORS(middleware.OpcoesCORS{
+		Origens:        cfg.CORSAllowedOrigins,
+		Metodos:        cfg.CORSAllowedMethods,
+		Cabecalhos:     cfg.CORSAllowedHeaders,
+		Expostos:       cfg.CORSExposedHeaders,
+		Credenciais:    cfg.CORSAllowCredentials,
+		CachePreflight: cfg.CORSMaxAge,
+	})(handler)
 
 	server := &http.Server{
 		Addr:         ":" + cfg.ServerPort,
-		Handler:      router,
+		Handler:      middleware.RequestID(handler),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
+		ErrorLog:     slog.NewLogLogger(slog.Default().Handler(), slog.LevelError),
 	}
 
 	go func() {
-		log.Printf("Servidor iniciado na porta %s", cfg.ServerPort)
+		slog.Info("servidor iniciado", "porta", cfg.ServerPort, "versao", AppVersion, "driver", cfg.DBDriver)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Erro ao iniciar servidor: %v", err)
+			encerrarComErro("erro ao iniciar servidor", err)
 		}
 	}()
 
@@ -90,13 +181,73 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Desligando servidor...")
+	slog.Info("desligando servidor")
+	pararPrecos()
+	healthHandler.Encerrar()
+	if cfg.ShutdownDelay > 0 {
+		slog.Info("aguardando o orquestrador remover a instância do balanceamento", "espera", cfg.ShutdownDelay.String())
+		time.Sleep(cfg.ShutdownDelay)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Erro ao desligar servidor: %v", err)
+		encerrarComErro("erro ao desligar servidor", err)
+	}
+
+	if err := encerrarTelemetria(ctx); err != nil {
+		slog.Warn("erro ao enviar os últimos spans", "erro", err)
+	}
+
+	slog.Info("servidor encerrado")
+}
+
+// aplicarPrecosPeriodicamente grava no cadastro os preços agendados que já
+// entraram em vigor. O checkout consulta o histórico e não depende disto; o
+// cadastro só é atualizado para listagens e buscas. Várias instâncias podem
+// rodar ao mesmo tempo, pois a aplicação é idempotente.
+func aplicarPrecosPeriodicamente(ctx context.Context, produtoService ports.ProdutoService, intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := produtoService.AplicarPrecosVigentes(ctx); err != nil && ctx.Err() == nil {
+				slog.Warn("erro ao aplicar os preços agendados", "erro", err)
+			}
+		}
+	}
+}
+
+// verificarMigracoes avisa quando o esquema está desatualizado. A API não
+// migra o banco sozinha: isso é feito pelo subcomando "migrate".
+func verificarMigracoes(db *sql.DB, driver string) {
+	migrador, err := database.NovoMigrador(db, driver)
+	if err != nil {
+		encerrarComErro("erro ao carregar migrações", err)
+	}
+
+	pendentes, err := migrador.Pendentes(context.Background())
+	if err != nil {
+		slog.Warn("não foi possível verificar as migrações", "erro", err)
+		return
+	}
+	if pendentes > 0 {
+		slog.Warn("existem migrações pendentes; execute \"soat-fiap migrate up\"", "pendentes", pendentes)
 	}
+}
+
+// configurarLog troca o logger padrão por JSON no nível de LOG_LEVEL, já
+// validado por config.Carregar. O pacote log também passa a escrever por ele.
+func configurarLog(nivel string) {
+	log, _ := logger.Novo(os.Stdout, nivel)
+	slog.SetDefault(log)
+}
 
-	log.Println("Servidor encerrado")
+func encerrarComErro(mensagem string, err error) {
+	slog.Error(mensagem, "erro", err)
+	os.Exit(1)
 }
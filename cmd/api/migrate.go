@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	config "soat-fiap/configs"
+	"soat-fiap/pkg/database"
+)
+
+const usoMigrate = `uso: soat-fiap migrate <comando>
+
+comandos:
+  up            aplica todas as migrações pendentes
+  down [n]      reverte as últimas n migrações (padrão 1)
+  to <versao>   aplica ou reverte até a versão informada (0 reverte tudo)
+  status        lista as migrações e se já foram aplicadas`
+
+// executarMigrate implementa o subcomando "migrate", separado da subida do
+// servidor para que o esquema seja atualizado uma única vez por deploy.
+func executarMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usoMigrate)
+		os.Exit(2)
+	}
+
+	if cfg.DBDriver == config.DriverMemoria {
+		encerrarComErro("driver sem migrações", fmt.Errorf("o driver %s não usa migrações", cfg.DBDriver))
+	}
+
+	db, err := conectarBanco(cfg)
+	if err != nil {
+		encerrarComErro("erro ao conectar ao banco de dados", err)
+	}
+	defer db.Close()
+
+	migrador, err := database.NovoMigrador(db, cfg.DBDriver)
+	if err != nil {
+		encerrarComErro("erro ao carregar migrações", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		err = migrador.Subir(ctx)
+	case "down":
+		passos := 1
+		if len(args) > 1 {
+			passos, err = strconv.Atoi(args[1])
+			if err != nil || passos < 1 {
+				encerrarComErro("quantidade de migrações inválida", fmt.Errorf("esperado inteiro positivo, obtido %q", args[1]))
+			}
+		}
+		err = migrador.Descer(ctx, passos)
+	case "to":
+		if len(args) < 2 {
+			encerrarComErro("informe a versão de destino", errors.New("versão ausente"))
+		}
+		versao, convErr := strconv.Atoi(args[1])
+		if convErr != nil || versao < 0 {
+			encerrarComErro("versão inválida", fmt.Errorf("esperado inteiro não negativo, obtido %q", args[1]))
+		}
+		err = migrador.Para(ctx, versao)
+	case "status":
+		err = imprimirStatus(ctx, migrador)
+	default:
+		fmt.Fprintln(os.Stderr, usoMigrate)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		encerrarComErro("erro ao executar migrações", err)
+	}
+}
+
+func imprimirStatus(ctx context.Context, migrador *database.Migrador) error {
+	estados, err := migrador.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSÃO\tNOME\tAPLICADA EM")
+	for _, estado := range estados {
+		aplicadaEm := "pendente"
+		if estado.Aplicada {
+			aplicadaEm = estado.AplicadaEm.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", estado.Versao, estado.Nome, aplicadaEm)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"database/sql"
+	"log/slog"
+
+	config "soat-fiap/configs"
+	"soat-fiap/internal/adapters/secondary/repositories"
+	"soat-fiap/internal/adapters/secondary/repositories/memoria"
+	"soat-fiap/internal/adapters/secondary/repositories/postgres"
+	"soat-fiap/internal/adapters/secondary/repositories/sqlite"
+	"soat-fiap/internal/adapters/secondary/saude"
+	"soat-fiap/internal/core/ports"
+	"soat-fiap/pkg/database"
+)
+
+// repositorios agrupa os adaptadores de persistência escolhidos por DB_DRIVER.
+type repositorios struct {
+	clientes   ports.ClienteRepository
+	produtos   ports.ProdutoRepository
+	categorias ports.CategoriaRepository
+	pedidos    ports.PedidoRepository
+	fechar     func() error
+	// db é nil nos repositórios em memória.
+	db *sql.DB
+	// verificadores são as dependências checadas pela prontidão.
+	verificadores []ports.VerificadorSaude
+}
+
+func abrirRepositorios(cfg *config.Config) (*repositorios, error) {
+	if cfg.DBDriver == config.DriverMemoria {
+		slog.Warn("usando repositórios em memória; os dados serão perdidos ao encerrar")
+		pedidos := memoria.NovoPedidoRepository()
+		produtos := memoria.NovoProdutoRepository(pedidos)
+		return &repositorios{
+			clientes:   memoria.NovoClienteRepository(),
+			produtos:   produtos,
+			categorias: memoria.NovoCategoriaRepository(produtos),
+			pedidos:    pedidos,
+			fechar:     func() error { return nil },
+		}, nil
+	}
+
+	db, err := conectarBanco(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	verificarMigracoes(db, cfg.DBDriver)
+
+	verificadorBanco, err := saude.NovoVerificadorBanco(db, cfg.DBDriver, cfg.HealthCheckTimeout)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	repos := &repositorios{
+		fechar:        db.Close,
+		db:            db,
+		verificadores: []ports.VerificadorSaude{verificadorBanco},
+	}
+	switch cfg.DBDriver {
+	case config.DriverPostgres:
+		repos.clientes = postgres.NovoClienteRepository(db)
+		repos.produtos = postgres.NovoProdutoRepository(db)
+		repos.categorias = postgres.NovoCategoriaRepository(db)
+		repos.pedidos = postgres.NovoPedidoRepository(db)
+	case config.DriverSQLite:
+		repos.clientes = sqlite.NovoClienteRepository(db)
+		repos.produtos = sqlite.NovoProdutoRepository(db)
+		repos.categorias = sqlite.NovoCategoriaRepository(db)
+		repos.pedidos = sqlite.NovoPedidoRepository(db)
+	default:
+		repos.clientes = repositories.NovoClienteRepository(db)
+		repos.produtos = repositories.NovoProdutoRepository(db)
+		repos.categorias = repositories.NovoCategoriaRepository(db)
+		repos.pedidos = repositories.NovoPedidoRepository(db)
+	}
+	return repos, nil
+}
+
+// conectarBanco abre a conexão dos drivers que usam database/sql.
+func conectarBanco(cfg *config.Config) (*sql.DB, error) {
+	return database.Conectar(database.Conexao{
+		Driver:  cfg.DBDriver,
+		Host:    cfg.DBHost,
+		Porta:   cfg.DBPort,
+		Usuario: cfg.DBUser,
+		Senha:   cfg.DBPassword,
+		Banco:   cfg.DBName,
+		Caminho: cfg.DBPath,
+
+		TLS:          cfg.DBTLS,
+		TempoConexao: cfg.DBDialTimeout,
+		TempoLeitura: cfg.DBReadTimeout,
+		TempoEscrita: cfg.DBWriteTimeout,
+		Pool: database.Pool{
+			MaxAbertas:       cfg.DBMaxOpenConns,
+			MaxOciosas:       cfg.DBMaxIdleConns,
+			VidaMaxima:       cfg.DBConnMaxLifetime,
+			OciosidadeMaxima: cfg.DBConnMaxIdleTime,
+		},
+		PrazoInicializacao: cfg.DBStartupTimeout,
+	})
+}
@@ -0,0 +1,117 @@
+// Command openapi converte a especificação Swagger 2.0 gerada pelo swag
+// (docs/swagger.json) em OpenAPI 3 (docs/openapi.json). É o arquivo OpenAPI 3
+// que a API embute e serve em /openapi.json. Rode com "make docs".
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	tipoJSON       = "application/json"
+	tipoProblema   = "application/problem+json"
+	tipoMergePatch = "application/merge-patch+json"
+	refProblema    = "#/components/schemas/handlers.Problema"
+	prefixoSchemas = "#/components/schemas/"
+)
+
+func main() {
+	entrada := flag.String("entrada", "docs/swagger.json", "especificação Swagger 2.0 gerada pelo swag")
+	saida := flag.String("saida", "docs/openapi.json", "arquivo OpenAPI 3 a gerar")
+	flag.Parse()
+
+	if err := converter(*entrada, *saida); err != nil {
+		fmt.Fprintln(os.Stderr, "openapi:", err)
+		os.Exit(1)
+	}
+}
+
+func converter(entrada, saida string) error {
+	conteudo, err := os.ReadFile(entrada)
+	if err != nil {
+		return err
+	}
+
+	var v2 openapi2.T
+	if err := json.Unmarshal(conteudo, &v2); err != nil {
+		return fmt.Errorf("%s: %w", entrada, err)
+	}
+
+	v3, err := openapi2conv.ToV3(&v2)
+	if err != nil {
+		return fmt.Errorf("erro ao converter para OpenAPI 3: %w", err)
+	}
+
+	// O host e a versão são preenchidos pela API ao servir o arquivo; aqui
+	// fica só o caminho base, relativo a quem estiver servindo.
+	v3.Servers = openapi3.Servers{{URL: v2.BasePath}}
+	marcarProblemas(v3)
+	permitirRemocaoEmMapas(v3)
+
+	if err := v3.Validate(context.Background()); err != nil {
+		return fmt.Errorf("especificação inválida: %w", err)
+	}
+
+	gerado, err := json.MarshalIndent(v3, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(saida, append(gerado, '\n'), 0o644)
+}
+
+// marcarProblemas troca o tipo das respostas de erro para
+// application/problem+json, que é o que responderErro envia. O swag só sabe
+// declarar um tipo por operação.
+func marcarProblemas(doc *openapi3.T) {
+	for _, item := range doc.Paths.Map() {
+		for _, operacao := range item.Operations() {
+			for _, resposta := range operacao.Responses.Map() {
+				conteudo := resposta.Value.Content
+				midia, ok := conteudo[tipoJSON]
+				if !ok || midia.Schema == nil || midia.Schema.Ref != refProblema {
+					continue
+				}
+				delete(conteudo, tipoJSON)
+				conteudo[tipoProblema] = midia
+			}
+		}
+	}
+}
+
+// permitirRemocaoEmMapas aceita null como valor dos mapas (como traducoes)
+// nos corpos JSON Merge Patch, em que null remove a chave (RFC 7396). O swag
+// não tem como declarar valores anuláveis em additionalProperties.
+func permitirRemocaoEmMapas(doc *openapi3.T) {
+	for _, item := range doc.Paths.Map() {
+		for _, operacao := range item.Operations() {
+			if operacao.RequestBody == nil || operacao.RequestBody.Value == nil {
+				continue
+			}
+			midia := operacao.RequestBody.Value.Content.Get(tipoMergePatch)
+			if midia == nil || midia.Schema == nil {
+				continue
+			}
+			esquema, ok := doc.Components.Schemas[strings.TrimPrefix(midia.Schema.Ref, prefixoSchemas)]
+			if !ok || esquema.Value == nil {
+				continue
+			}
+			for _, propriedade := range esquema.Value.Properties {
+				if propriedade.Value == nil {
+					continue
+				}
+				if valores := propriedade.Value.AdditionalProperties.Schema; valores != nil && valores.Value != nil {
+					valores.Value.Nullable = true
+				}
+			}
+		}
+	}
+}
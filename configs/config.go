@@ -1,66 +1,329 @@
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Drivers de banco suportados em DB_DRIVER.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemoria  = "memory"
+)
+
+// Origens possíveis de cada valor, da menor para a maior precedência.
+const (
+	origemPadrao   = "padrão"
+	origemArquivo  = "arquivo"
+	origemAmbiente = "ambiente"
+	origemSegredo  = "ambiente (_FILE)"
+	origemFlag     = "flag"
 )
 
 type Config struct {
 	ServerPort    string
+	DBDriver      string
 	DBHost        string
 	DBPort        string
 	DBUser        string
 	DBPassword    string
 	DBName        string
+	DBPath        string
 	LogLevel      string
 	SwaggerEnable bool
+
+	// DBTLS aceita disable, prefer, require ou verify.
+	DBTLS             string
+	DBDialTimeout     time.Duration
+	DBReadTimeout     time.Duration
+	DBWriteTimeout    time.Duration
+	DBStartupTimeout  time.Duration
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+
+	// HealthCheckTimeout limita cada verificação da prontidão.
+	HealthCheckTimeout time.Duration
+	// ShutdownDelay é quanto a API continua atendendo, já com a prontidão
+	// falhando, antes de parar de aceitar conexões.
+	ShutdownDelay time.Duration
+
+	// StorageDir é o diretório onde ficam os arquivos enviados, como as
+	// imagens dos produtos, e StoragePublicURL o endereço em que são
+	// publicados. Um caminho (ex.: /arquivos) é servido pela própria API; uma
+	// URL completa aponta para um servidor de arquivos ou CDN.
+	StorageDir       string
+	StoragePublicURL string
+	// ImageMaxSize é o tamanho máximo, em bytes, de uma imagem enviada.
+	ImageMaxSize int
+
+	// PriceApplyInterval é o intervalo em que os preços agendados que
+	// entraram em vigor são gravados no cadastro dos produtos. Zero desliga.
+	PriceApplyInterval time.Duration
+
+	// TracingEnable liga o OpenTelemetry. Os spans vão para TracingEndpoint
+	// (OTLP/HTTP) ou, se vazio, para a saída padrão.
+	TracingEnable   bool
+	TracingEndpoint string
+	// TracingSampleRatio é a fração de traces gravados, de 0 a 1.
+	TracingSampleRatio float64
+
+	// RequestValidation valida as requisições contra a especificação
+	// OpenAPI; RequestValidationStrict também rejeita campos desconhecidos.
+	RequestValidation       bool
+	RequestValidationStrict bool
+	// IfMatchRequired exige If-Match nas alterações (428 quando ausente).
+	IfMatchRequired bool
+
+	// CORS: origens aceitas ("*" ou esquema://host[:porta], com *. no início
+	// do host para subdomínios). Sem origens, o CORS fica desligado.
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSExposedHeaders   []string
+	CORSAllowCredentials bool
+	CORSMaxAge           time.Duration
+
+	// HSTSMaxAge é enviado em Strict-Transport-Security nas respostas HTTPS;
+	// zero desliga o cabeçalho.
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	// CSP vale para as respostas da API e CSPDocs para o Swagger UI.
+	CSP     string
+	CSPDocs string
+
+	origens map[string]string
 }
 
-func LoadConfig() *Config {
-	serverPort := getEnvOrPanic("SERVER_PORT", "8080")
-	dbHost := getEnvOrPanic("DB_HOST", "")
-	dbPort := getEnvOrPanic("DB_PORT", "")
-	dbUser := getEnvOrPanic("DB_USER", "")
-	dbPassword := getEnvOrPanic("DB_PASSWORD", "")
-	dbName := getEnvOrPanic("DB_NAME", "")
-	logLevel := getEnv("LOG_LEVEL", "info")
-	swaggerEnable := getEnvAsBool("SWAGGER_ENABLE", true)
-
-	return &Config{
-		ServerPort:    serverPort,
-		DBHost:        dbHost,
-		DBPort:        dbPort,
-		DBUser:        dbUser,
-		DBPassword:    dbPassword,
-		DBName:        dbName,
-		LogLevel:      logLevel,
-		SwaggerEnable: swaggerEnable,
-	}
+// opcao descreve uma configuração pelo nome da variável de ambiente. A chave
+// no arquivo e a flag derivam dele: DB_HOST é db_host (ou db: {host: ...})
+// no arquivo e --db-host na linha de comando.
+type opcao struct {
+	nome      string
+	padrao    string
+	segredo   bool
+	descricao string
+	campo     func(*Config) any
+}
+
+var opcoes = []opcao{
+	{nome: "SERVER_PORT", padrao: "8080", descricao: "porta HTTP da API", campo: func(c *Config) any { return &c.ServerPort }},
+	{nome: "DB_DRIVER", padrao: DriverMySQL, descricao: "mysql, postgres, sqlite ou memory", campo: func(c *Config) any { return &c.DBDriver }},
+	{nome: "DB_HOST", descricao: "host do banco (mysql/postgres)", campo: func(c *Config) any { return &c.DBHost }},
+	{nome: "DB_PORT", descricao: "porta do banco (mysql/postgres)", campo: func(c *Config) any { return &c.DBPort }},
+	{nome: "DB_USER", descricao: "usuário do banco (mysql/postgres)", campo: func(c *Config) any { return &c.DBUser }},
+	{nome: "DB_PASSWORD", segredo: true, descricao: "senha do banco (mysql/postgres)", campo: func(c *Config) any { return &c.DBPassword }},
+	{nome: "DB_NAME", descricao: "nome do banco (mysql/postgres)", campo: func(c *Config) any { return &c.DBName }},
+	{nome: "DB_PATH", padrao: "soat-fiap.db", descricao: "arquivo do banco (sqlite)", campo: func(c *Config) any { return &c.DBPath }},
+	{nome: "LOG_LEVEL", padrao: "info", descricao: "debug, info, warn ou error", campo: func(c *Config) any { return &c.LogLevel }},
+	{nome: "SWAGGER_ENABLE", padrao: "true", descricao: "publica a documentação Swagger", campo: func(c *Config) any { return &c.SwaggerEnable }},
+
+	{nome: "DB_TLS", padrao: "disable", descricao: "disable, prefer, require ou verify", campo: func(c *Config) any { return &c.DBTLS }},
+	{nome: "DB_DIAL_TIMEOUT", padrao: "5s", descricao: "tempo máximo para abrir uma conexão", campo: func(c *Config) any { return &c.DBDialTimeout }},
+	{nome: "DB_READ_TIMEOUT", padrao: "30s", descricao: "tempo máximo de cada leitura (mysql)", campo: func(c *Config) any { return &c.DBReadTimeout }},
+	{nome: "DB_WRITE_TIMEOUT", padrao: "30s", descricao: "tempo máximo de cada escrita (mysql)", campo: func(c *Config) any { return &c.DBWriteTimeout }},
+	{nome: "DB_STARTUP_TIMEOUT", padrao: "60s", descricao: "prazo para o banco responder na subida", campo: func(c *Config) any { return &c.DBStartupTimeout }},
+	{nome: "DB_MAX_OPEN_CONNS", padrao: "25", descricao: "conexões abertas no pool", campo: func(c *Config) any { return &c.DBMaxOpenConns }},
+	{nome: "DB_MAX_IDLE_CONNS", padrao: "10", descricao: "conexões ociosas no pool", campo: func(c *Config) any { return &c.DBMaxIdleConns }},
+	{nome: "DB_CONN_MAX_LIFETIME", padrao: "5m", descricao: "idade máxima de uma conexão", campo: func(c *Config) any { return &c.DBConnMaxLifetime }},
+	{nome: "DB_CONN_MAX_IDLE_TIME", padrao: "2m", descricao: "tempo máximo de uma conexão ociosa", campo: func(c *Config) any { return &c.DBConnMaxIdleTime }},
+
+	{nome: "HEALTH_CHECK_TIMEOUT", padrao: "2s", descricao: "limite de cada verificação da prontidão", campo: func(c *Config) any { return &c.HealthCheckTimeout }},
+	{nome: "SHUTDOWN_DELAY", padrao: "0s", descricao: "espera antes de parar de aceitar conexões", campo: func(c *Config) any { return &c.ShutdownDelay }},
+	{nome: "STORAGE_DIR", padrao: "uploads", descricao: "diretório dos arquivos enviados", campo: func(c *Config) any { return &c.StorageDir }},
+	{nome: "STORAGE_PUBLIC_URL", padrao: "/arquivos", descricao: "endereço público dos arquivos; um caminho é servido pela própria API", campo: func(c *Config) any { return &c.StoragePublicURL }},
+	{nome: "IMAGE_MAX_SIZE", padrao: "5242880", descricao: "tamanho máximo, em bytes, das imagens enviadas", campo: func(c *Config) any { return &c.ImageMaxSize }},
+	{nome: "PRICE_APPLY_INTERVAL", padrao: "1m", descricao: "intervalo para aplicar ao cadastro os preços agendados que entraram em vigor; 0 desliga", campo: func(c *Config) any { return &c.PriceApplyInterval }},
+
+	{nome: "TRACING_ENABLE", padrao: "false", descricao: "liga o rastreamento OpenTelemetry", campo: func(c *Config) any { return &c.TracingEnable }},
+	{nome: "OTEL_EXPORTER_OTLP_ENDPOINT", descricao: "URL OTLP/HTTP do coletor; vazio usa a saída padrão", campo: func(c *Config) any { return &c.TracingEndpoint }},
+	{nome: "TRACING_SAMPLE_RATIO", padrao: "1", descricao: "fração de traces gravados, de 0 a 1", campo: func(c *Config) any { return &c.TracingSampleRatio }},
+
+	{nome: "REQUEST_VALIDATION", padrao: "true", descricao: "valida as requisições contra a especificação OpenAPI", campo: func(c *Config) any { return &c.RequestValidation }},
+	{nome: "REQUEST_VALIDATION_STRICT", padrao: "false", descricao: "rejeita campos fora da especificação OpenAPI", campo: func(c *Config) any { return &c.RequestValidationStrict }},
+	{nome: "IF_MATCH_REQUIRED", padrao: "false", descricao: "exige If-Match em PUT e PATCH", campo: func(c *Config) any { return &c.IfMatchRequired }},
+
+	{nome: "CORS_ALLOWED_ORIGINS", descricao: "origens aceitas pelo CORS, separadas por vírgula; vazio desliga o CORS", campo: func(c *Config) any { return &c.CORSAllowedOrigins }},
+	{nome: "CORS_ALLOWED_METHODS", padrao: "GET,POST,PUT,PATCH,DELETE", descricao: "métodos aceitos pelo CORS", campo: func(c *Config) any { return &c.CORSAllowedMethods }},
+	{nome: "CORS_ALLOWED_HEADERS", padrao: "Content-Type,If-Match,X-Request-ID,traceparent,tracestate", descricao: "cabeçalhos aceitos pelo CORS", campo: func(c *Config) any { return &c.CORSAllowedHeaders }},
+	{nome: "CORS_EXPOSED_HEADERS", padrao: "Link,X-Next-Cursor,ETag,X-Request-ID,Retry-After", descricao: "cabeçalhos de resposta visíveis ao navegador", campo: func(c *Config) any { return &c.CORSExposedHeaders }},
+	{nome: "CORS_ALLOW_CREDENTIALS", padrao: "false", descricao: "permite cookies e credenciais nas requisições CORS", campo: func(c *Config) any { return &c.CORSAllowCredentials }},
+	{nome: "CORS_MAX_AGE", padrao: "10m", descricao: "tempo de cache do preflight no navegador", campo: func(c *Config) any { return &c.CORSMaxAge }},
+
+	{nome: "HSTS_MAX_AGE", padrao: "4320h", descricao: "max-age do Strict-Transport-Security; 0 desliga", campo: func(c *Config) any { return &c.HSTSMaxAge }},
+	{nome: "HSTS_INCLUDE_SUBDOMAINS", padrao: "false", descricao: "inclui includeSubDomains no HSTS", campo: func(c *Config) any { return &c.HSTSIncludeSubdomains }},
+	{nome: "CSP", padrao: "default-src 'none'; frame-ancestors 'none'", descricao: "Content-Security-Policy das respostas da API", campo: func(c *Config) any { return &c.CSP }},
+	{nome: "CSP_DOCS", padrao: "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'", descricao: "Content-Security-Policy do Swagger UI", campo: func(c *Config) any { return &c.CSPDocs }},
 }
 
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+// Problemas reúne tudo o que está errado na configuração, para que seja
+// corrigido de uma vez em vez de um erro por execução.
+type Problemas []string
+
+func (p Problemas) Error() string {
+	return "configuração inválida: " + strings.Join(p, "; ")
+}
+
+// Carregar monta a configuração em camadas: valores padrão, arquivo YAML ou
+// TOML (--config ou CONFIG_FILE), variáveis de ambiente (com NOME_FILE para
+// ler o valor de um arquivo, como os secrets do Docker) e flags. Devolve os
+// argumentos que sobraram depois das flags (o subcomando) e, se algo estiver
+// inválido, Problemas com todas as falhas encontradas.
+func Carregar(args []string) (cfg *Config, resto []string, err error) {
+	valores := make(map[string]string, len(opcoes))
+	origens := make(map[string]string, len(opcoes))
+	for _, o := range opcoes {
+		valores[o.nome] = o.padrao
+		origens[o.nome] = origemPadrao
+	}
+
+	flags := flag.NewFlagSet("soat-fiap", flag.ContinueOnError)
+	arquivo := flags.String("config", os.Getenv("CONFIG_FILE"), "arquivo de configuração YAML ou TOML")
+	daLinhaDeComando := make(map[string]string)
+	for _, o := range opcoes {
+		registrar := flags.Func
+		if _, booleano := o.campo(&Config{}).(*bool); booleano {
+			// Permite --swagger-enable sem valor, como nas flags booleanas.
+			registrar = flags.BoolFunc
+		}
+		registrar(nomeFlag(o.nome), o.descricao, func(valor string) error {
+			daLinhaDeComando[o.nome] = valor
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	var problemas Problemas
+
+	if *arquivo != "" {
+		doArquivo, err := lerArquivo(*arquivo)
+		if err != nil {
+			problemas = append(problemas, err.Error())
+		}
+		for nome, valor := range doArquivo {
+			valores[nome] = valor
+			origens[nome] = origemArquivo
+		}
+	}
+
+	for _, o := range opcoes {
+		valor, origem, err := lerAmbiente(o.nome)
+		if err != nil {
+			problemas = append(problemas, err.Error())
+			continue
+		}
+		if origem != "" {
+			valores[o.nome] = valor
+			origens[o.nome] = origem
+		}
 	}
-	return defaultValue
+
+	for nome, valor := range daLinhaDeComando {
+		valores[nome] = valor
+		origens[nome] = origemFlag
+	}
+
+	cfg = &Config{origens: origens}
+	for _, o := range opcoes {
+		if err := converter(valores[o.nome], o.campo(cfg)); err != nil {
+			problemas = append(problemas, fmt.Sprintf("%s: %v", o.nome, err))
+		}
+	}
+	problemas = append(problemas, cfg.validar()...)
+
+	if len(problemas) > 0 {
+		return nil, nil, problemas
+	}
+	return cfg, flags.Args(), nil
 }
 
-func getEnvOrPanic(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+// Imprimir escreve a configuração efetiva, com a origem de cada valor e os
+// segredos mascarados.
+func (c *Config) Imprimir(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NOME\tVALOR\tORIGEM")
+	for _, o := range opcoes {
+		valor := formatar(o.campo(c))
+		if o.segredo && valor != "" {
+			valor = "********"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", o.nome, valor, c.origens[o.nome])
 	}
-	if defaultValue != "" {
-		return defaultValue
+	return tw.Flush()
+}
+
+func nomeFlag(nome string) string {
+	return strings.ReplaceAll(strings.ToLower(nome), "_", "-")
+}
+
+func converter(valor string, destino any) error {
+	switch d := destino.(type) {
+	case *string:
+		*d = valor
+	case *bool:
+		v, err := strconv.ParseBool(valor)
+		if err != nil {
+			return fmt.Errorf("%q não é um booleano (use true ou false)", valor)
+		}
+		*d = v
+	case *int:
+		v, err := strconv.Atoi(valor)
+		if err != nil {
+			return fmt.Errorf("%q não é um número inteiro", valor)
+		}
+		*d = v
+	case *float64:
+		v, err := strconv.ParseFloat(valor, 64)
+		if err != nil {
+			return fmt.Errorf("%q não é um número", valor)
+		}
+		*d = v
+	case *time.Duration:
+		v, err := time.ParseDuration(valor)
+		if err != nil {
+			return fmt.Errorf("%q não é uma duração (ex.: 30s, 5m)", valor)
+		}
+		*d = v
+	case *[]string:
+		*d = nil
+		for _, item := range strings.Split(valor, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*d = append(*d, item)
+			}
+		}
+	default:
+		return errors.New("tipo de configuração não suportado")
 	}
-	panic(fmt.Sprintf("Variável de ambiente obrigatória não definida: %s", key))
+	return nil
 }
 
-func getEnvAsBool(key string, defaultValue bool) bool {
-	valueStr := getEnv(key, strconv.FormatBool(defaultValue))
-	value, err := strconv.ParseBool(valueStr)
-	if err != nil {
-		return defaultValue
+func formatar(campo any) string {
+	switch v := campo.(type) {
+	case *string:
+		return *v
+	case *bool:
+		return strconv.FormatBool(*v)
+	case *int:
+		return strconv.Itoa(*v)
+	case *float64:
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	case *time.Duration:
+		return v.String()
+	case *[]string:
+		return strings.Join(*v, ",")
+	default:
+		return ""
 	}
-	return value
 }
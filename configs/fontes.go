@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// lerArquivo lê um arquivo YAML (.yaml, .yml) ou TOML (.toml) e devolve os
+// valores pelo nome da opção. Seções aninhadas são achatadas com "_", então
+// db: {host: x} e db_host: x são equivalentes. Chaves desconhecidas são
+// reportadas para que erros de digitação não passem despercebidos.
+func lerArquivo(caminho string) (map[string]string, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("arquivo de configuração: %w", err)
+	}
+
+	var bruto map[string]any
+	switch strings.ToLower(filepath.Ext(caminho)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(conteudo, &bruto)
+	case ".toml":
+		err = toml.Unmarshal(conteudo, &bruto)
+	default:
+		return nil, fmt.Errorf("arquivo de configuração %s: use a extensão .yaml, .yml ou .toml", caminho)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("arquivo de configuração %s: %w", caminho, err)
+	}
+
+	conhecidas := make(map[string]bool, len(opcoes))
+	for _, o := range opcoes {
+		conhecidas[o.nome] = true
+	}
+
+	valores := make(map[string]string)
+	var desconhecidas []string
+	var achatar func(prefixo string, m map[string]any)
+	achatar = func(prefixo string, m map[string]any) {
+		for chave, valor := range m {
+			nome := strings.ToUpper(prefixo + chave)
+			if secao, ok := valor.(map[string]any); ok {
+				achatar(nome+"_", secao)
+				continue
+			}
+			if !conhecidas[nome] {
+				desconhecidas = append(desconhecidas, strings.ToLower(nome))
+				continue
+			}
+			if lista, ok := valor.([]any); ok {
+				// Listas (ex.: cors: {allowed_origins: [...]}) viram o mesmo
+				// formato separado por vírgulas das variáveis de ambiente.
+				itens := make([]string, len(lista))
+				for i, item := range lista {
+					itens[i] = fmt.Sprint(item)
+				}
+				valores[nome] = strings.Join(itens, ",")
+				continue
+			}
+			valores[nome] = fmt.Sprint(valor)
+		}
+	}
+	achatar("", bruto)
+
+	if len(desconhecidas) > 0 {
+		sort.Strings(desconhecidas)
+		return valores, fmt.Errorf("arquivo de configuração %s: chaves desconhecidas: %s", caminho, strings.Join(desconhecidas, ", "))
+	}
+	return valores, nil
+}
+
+// lerAmbiente lê NOME ou, para segredos montados como arquivo (ex.: Docker
+// secrets), o conteúdo do arquivo apontado por NOME_FILE. Origem vazia indica
+// que nenhuma das duas está definida.
+func lerAmbiente(nome string) (valor, origem string, err error) {
+	valor, direto := os.LookupEnv(nome)
+	caminho, arquivo := os.LookupEnv(nome + "_FILE")
+
+	switch {
+	case direto && arquivo:
+		return "", "", fmt.Errorf("%s: defina %s ou %s_FILE, não ambos", nome, nome, nome)
+	case arquivo:
+		conteudo, err := os.ReadFile(caminho)
+		if err != nil {
+			return "", "", fmt.Errorf("%s_FILE: %w", nome, err)
+		}
+		return strings.TrimRight(string(conteudo), "\r\n"), origemSegredo, nil
+	case direto:
+		return valor, origemAmbiente, nil
+	default:
+		return "", "", nil
+	}
+}
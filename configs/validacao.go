@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validar confere as regras que vão além do tipo de cada valor. Todos os
+// problemas são devolvidos juntos.
+func (c *Config) validar() Problemas {
+	var problemas Problemas
+	adicionar := func(nome, formato string, args ...any) {
+		problemas = append(problemas, nome+": "+fmt.Sprintf(formato, args...))
+	}
+
+	if !portaValida(c.ServerPort) {
+		adicionar("SERVER_PORT", "%q não é uma porta entre 1 e 65535", c.ServerPort)
+	}
+
+	switch c.DBDriver {
+	case DriverMySQL, DriverPostgres:
+		// O SQLite só precisa do caminho do arquivo e os repositórios em
+		// memória não usam banco, então host e credenciais só são exigidos
+		// para MySQL e PostgreSQL.
+		obrigatorios := []struct{ nome, valor string }{
+			{"DB_HOST", c.DBHost},
+			{"DB_PORT", c.DBPort},
+			{"DB_USER", c.DBUser},
+			{"DB_PASSWORD", c.DBPassword},
+			{"DB_NAME", c.DBName},
+		}
+		for _, o := range obrigatorios {
+			if o.valor == "" {
+				adicionar(o.nome, "obrigatório com DB_DRIVER=%s", c.DBDriver)
+			}
+		}
+		if c.DBPort != "" && !portaValida(c.DBPort) {
+			adicionar("DB_PORT", "%q não é uma porta entre 1 e 65535", c.DBPort)
+		}
+	case DriverSQLite:
+		if c.DBPath == "" {
+			adicionar("DB_PATH", "obrigatório com DB_DRIVER=%s", c.DBDriver)
+		}
+	case DriverMemoria:
+	default:
+		adicionar("DB_DRIVER", "%q inválido; use %s, %s, %s ou %s", c.DBDriver, DriverMySQL, DriverPostgres, DriverSQLite, DriverMemoria)
+	}
+
+	switch c.DBTLS {
+	case "disable", "prefer", "require", "verify":
+	default:
+		adicionar("DB_TLS", "%q inválido; use disable, prefer, require ou verify", c.DBTLS)
+	}
+
+	var nivel slog.Level
+	if nivel.UnmarshalText([]byte(c.LogLevel)) != nil {
+		adicionar("LOG_LEVEL", "%q inválido; use debug, info, warn ou error", c.LogLevel)
+	}
+
+	duracoes := []struct {
+		nome  string
+		valor time.Duration
+	}{
+		{"DB_DIAL_TIMEOUT", c.DBDialTimeout},
+		{"DB_READ_TIMEOUT", c.DBReadTimeout},
+		{"DB_WRITE_TIMEOUT", c.DBWriteTimeout},
+		{"DB_STARTUP_TIMEOUT", c.DBStartupTimeout},
+		{"DB_CONN_MAX_LIFETIME", c.DBConnMaxLifetime},
+		{"DB_CONN_MAX_IDLE_TIME", c.DBConnMaxIdleTime},
+		{"SHUTDOWN_DELAY", c.ShutdownDelay},
+		{"PRICE_APPLY_INTERVAL", c.PriceApplyInterval},
+	}
+	for _, d := range duracoes {
+		if d.valor < 0 {
+			adicionar(d.nome, "não pode ser negativo")
+		}
+	}
+	if c.HealthCheckTimeout <= 0 {
+		adicionar("HEALTH_CHECK_TIMEOUT", "deve ser maior que zero")
+	}
+
+	if c.DBMaxOpenConns < 0 {
+		adicionar("DB_MAX_OPEN_CONNS", "não pode ser negativo")
+	}
+	if c.DBMaxIdleConns < 0 {
+		adicionar("DB_MAX_IDLE_CONNS", "não pode ser negativo")
+	}
+	if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		adicionar("DB_MAX_IDLE_CONNS", "não pode ser maior que DB_MAX_OPEN_CONNS (%d)", c.DBMaxOpenConns)
+	}
+
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		adicionar("TRACING_SAMPLE_RATIO", "deve estar entre 0 e 1")
+	}
+	if c.TracingEndpoint != "" {
+		u, err := url.Parse(c.TracingEndpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			adicionar("OTEL_EXPORTER_OTLP_ENDPOINT", "%q não é uma URL http(s)", c.TracingEndpoint)
+		}
+	}
+
+	for _, origem := range c.CORSAllowedOrigins {
+		if origem == "*" {
+			if c.CORSAllowCredentials {
+				adicionar("CORS_ALLOWED_ORIGINS", "\"*\" não pode ser usado com CORS_ALLOW_CREDENTIALS=true; liste as origens")
+			}
+			continue
+		}
+		u, err := url.Parse(origem)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			adicionar("CORS_ALLOWED_ORIGINS", "%q não é uma origem (esquema://host[:porta])", origem)
+		}
+	}
+	if c.StorageDir == "" {
+		adicionar("STORAGE_DIR", "obrigatório")
+	}
+	if !urlPublicaValida(c.StoragePublicURL) {
+		adicionar("STORAGE_PUBLIC_URL", "%q não é um caminho (ex.: /arquivos) nem uma URL http(s)", c.StoragePublicURL)
+	}
+	if c.ImageMaxSize <= 0 {
+		adicionar("IMAGE_MAX_SIZE", "deve ser maior que zero")
+	}
+
+	if c.CORSMaxAge < 0 {
+		adicionar("CORS_MAX_AGE", "não pode ser negativo")
+	}
+	if c.HSTSMaxAge < 0 {
+		adicionar("HSTS_MAX_AGE", "não pode ser negativo")
+	}
+
+	return problemas
+}
+
+func portaValida(porta string) bool {
+	n, err := strconv.Atoi(porta)
+	return err == nil && n >= 1 && n <= 65535
+}
+
+// urlPublicaValida aceita um caminho absoluto, que não pode ser a raiz nem
+// começar com // (seria lido como outro host), ou uma URL http(s).
+func urlPublicaValida(valor string) bool {
+	if strings.HasPrefix(valor, "/") {
+		return !strings.HasPrefix(valor, "//") && strings.Trim(valor, "/") != ""
+	}
+	u, err := url.Parse(valor)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -24,29 +24,1273 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/clientes/removidos": {
+            "get": {
+                "description": "Uso administrativo: os clientes removidos não aparecem nas demais consultas, mas continuam nos pedidos antigos. Podem ser reativados com POST /clientes/{id}/restaurar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Listar clientes removidos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trecho do nome do cliente",
+                        "name": "nome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de itens (1 a 200, padrão 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor retornado pela página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "nome",
+                            "-nome",
+                            "created_at",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "description": "Campo de ordenação; prefixo - para decrescente",
+                        "name": "ordenar",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Cliente"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link para a próxima página"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor da próxima página"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Parâmetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/produtos/removidos": {
+            "get": {
+                "description": "Uso administrativo: os produtos removidos não aparecem nas demais consultas, mas continuam nos pedidos antigos. Podem ser reativados com POST /produtos/{id}/restaurar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Listar produtos removidos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Categoria do produto",
+                        "name": "categoria",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Preço mínimo (inclusivo)",
+                        "name": "preco_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Preço máximo (inclusivo)",
+                        "name": "preco_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Somente produtos disponíveis (true) ou indisponíveis (false)",
+                        "name": "disponivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de itens (1 a 200, padrão 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor retornado pela página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "nome",
+                            "-nome",
+                            "preco",
+                            "-preco",
+                            "created_at",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "description": "Campo de ordenação; prefixo - para decrescente",
+                        "name": "ordenar",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Produto"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link para a próxima página"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor da próxima página"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Parâmetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/categorias": {
+            "get": {
+                "description": "Ordenadas pelo campo ordem e, nos empates, pelo código. O cardápio deve usar ativa=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorias"
+                ],
+                "summary": "Listar categorias",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Somente categorias ativas (true) ou inativas (false)",
+                        "name": "ativa",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CategoriaProduto"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Parâmetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "O código é gravado nos produtos e não pode ser alterado depois: letras maiúsculas (acentos são aceitos), dígitos e _.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorias"
+                ],
+                "summary": "Criar categoria",
+                "parameters": [
+                    {
+                        "description": "Dados da categoria",
+                        "name": "categoria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CriarCategoriaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CategoriaProduto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão da categoria"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Código já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/categorias/{codigo}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorias"
+                ],
+                "summary": "Buscar categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código da categoria",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já obtido; responde 304 se a categoria não mudou",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CategoriaProduto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão da categoria"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Categoria não modificada",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Categoria não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Todos os campos são obrigatórios; para alterar só alguns, use PATCH. O código não pode ser alterado. Desativar a categoria a esconde do cardápio e impede novos produtos nela, sem alterar os produtos existentes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorias"
+                ],
+                "summary": "Atualizar categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código da categoria",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados da categoria",
+                        "name": "categoria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AtualizarCategoriaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CategoriaProduto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão da categoria"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Categoria não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Categoria alterada simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Categorias com produtos, mesmo removidos, não podem ser apagadas; desative-as com PATCH {\"ativa\": false}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorias"
+                ],
+                "summary": "Deletar categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código da categoria",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Categoria deletada",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Categoria não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Categoria com produtos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "JSON Merge Patch (RFC 7396): campos ausentes mantêm o valor atual; em traducoes, um idioma com null é removido. codigo, versao e as datas não podem ser alterados.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorias"
+                ],
+                "summary": "Atualizar categoria parcialmente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código da categoria",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "categoria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AtualizarCategoriaParcialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CategoriaProduto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão da categoria"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Categoria não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Categoria alterada simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
         "/clientes": {
             "get": {
+                "description": "A próxima página é indicada pelos cabeçalhos Link (rel=\"next\") e X-Next-Cursor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Listar clientes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trecho do nome do cliente",
+                        "name": "nome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de itens (1 a 200, padrão 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor retornado pela página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "nome",
+                            "-nome",
+                            "created_at",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "description": "Campo de ordenação; prefixo - para decrescente",
+                        "name": "ordenar",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Cliente"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link para a próxima página"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor da próxima página"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Parâmetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Criar cliente",
+                "parameters": [
+                    {
+                        "description": "Dados do cliente",
+                        "name": "cliente",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CriarClienteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do cliente"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "CPF já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/clientes/cpf/{cpf}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Buscar cliente por CPF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "CPF do cliente",
+                        "name": "cpf",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já obtido; responde 304 se o cliente não mudou",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do cliente"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cliente não modificado",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "CPF inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/clientes/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Buscar cliente por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já obtido; responde 304 se o cliente não mudou",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do cliente"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cliente não modificado",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Todos os campos são obrigatórios; para alterar só alguns, use PATCH.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Atualizar cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do cliente",
+                        "name": "cliente",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AtualizarClienteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do cliente"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "CPF já cadastrado ou cliente alterado simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "O cliente deixa de aparecer nas consultas, mas continua nos pedidos antigos e pode ser restaurado. O CPF continua reservado para ele.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Deletar cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cliente deletado",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "JSON Merge Patch (RFC 7396): campos ausentes mantêm o valor atual. id, versao e as datas não podem ser alterados.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Atualizar cliente parcialmente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "cliente",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AtualizarClienteParcialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do cliente"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "CPF já cadastrado ou cliente alterado simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/clientes/{id}/restaurar": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes"
+                ],
+                "summary": "Restaurar cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do cliente"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Cliente não está removido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Equivalente a /health/live, mantido por compatibilidade.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Alguma dependência indisponível ou API encerrando",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pedidos": {
+            "get": {
+                "description": "Sem ordenação explícita, retorna os mais recentes primeiro ou, com filtro de status, os mais antigos primeiro.\nA próxima página é indicada pelos cabeçalhos Link (rel=\"next\") e X-Next-Cursor.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "pedidos"
+                ],
+                "summary": "Listar pedidos",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Status do pedido (repetido ou separado por vírgulas)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "cliente_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados a partir de (AAAA-MM-DD ou RFC 3339, inclusivo)",
+                        "name": "de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados antes de (AAAA-MM-DD ou RFC 3339, exclusivo)",
+                        "name": "ate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de itens (1 a 200, padrão 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor retornado pela página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "-created_at",
+                            "updated_at",
+                            "-updated_at",
+                            "valor_total",
+                            "-valor_total"
+                        ],
+                        "type": "string",
+                        "description": "Campo de ordenação; prefixo - para decrescente",
+                        "name": "ordenar",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Listar clientes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Cliente"
+                                "$ref": "#/definitions/domain.Pedido"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link para a próxima página"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor da próxima página"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Erro ao listar clientes",
+                    "422": {
+                        "description": "Parâmetros inválidos",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
@@ -59,17 +1303,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "pedidos"
                 ],
-                "summary": "Criar cliente",
+                "summary": "Criar pedido",
                 "parameters": [
                     {
-                        "description": "Dados do cliente",
-                        "name": "cliente",
+                        "description": "Dados do pedido",
+                        "name": "pedido",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CriarClienteRequest"
+                            "$ref": "#/definitions/handlers.CriarPedidoRequest"
                         }
                     }
                 ],
@@ -77,223 +1321,447 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Cliente"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Erro ao criar cliente",
+                        "description": "Requisição inválida",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Itens inválidos, inexistentes ou indisponíveis",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
             }
         },
-        "/clientes/cpf/{cpf}": {
+        "/pedidos/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "pedidos"
                 ],
-                "summary": "Buscar cliente por CPF",
+                "summary": "Buscar pedido por ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "CPF do cliente",
-                        "name": "cpf",
+                        "description": "ID do pedido",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag já obtido; responde 304 se o pedido não mudou",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Cliente"
+                            "$ref": "#/definitions/domain.Pedido"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do pedido"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Cliente não encontrado",
+                    "304": {
+                        "description": "Pedido não modificado",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
                     }
                 }
             }
         },
-        "/clientes/{id}": {
-            "get": {
+        "/pedidos/{id}/status": {
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "pedidos"
                 ],
-                "summary": "Buscar cliente por ID",
+                "summary": "Atualizar status do pedido",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do cliente",
+                        "description": "ID do pedido",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Novo status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AtualizarStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Cliente"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do pedido"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     },
                     "404": {
-                        "description": "Cliente não encontrado",
+                        "description": "Pedido não encontrado",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Pedido alterado simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Status inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/produtos": {
+            "get": {
+                "description": "A próxima página é indicada pelos cabeçalhos Link (rel=\"next\") e X-Next-Cursor.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "produtos"
                 ],
-                "summary": "Atualizar cliente",
+                "summary": "Listar produtos",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do cliente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Categoria do produto",
+                        "name": "categoria",
+                        "in": "query"
                     },
                     {
-                        "description": "Dados do cliente",
-                        "name": "cliente",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AtualizarClienteRequest"
-                        }
+                        "type": "number",
+                        "description": "Preço mínimo (inclusivo)",
+                        "name": "preco_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Preço máximo (inclusivo)",
+                        "name": "preco_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Somente produtos disponíveis (true) ou indisponíveis (false)",
+                        "name": "disponivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de itens (1 a 200, padrão 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor retornado pela página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "nome",
+                            "-nome",
+                            "preco",
+                            "-preco",
+                            "created_at",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "description": "Campo de ordenação; prefixo - para decrescente",
+                        "name": "ordenar",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Cliente"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Produto"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Link para a próxima página"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor da próxima página"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Erro ao atualizar cliente",
+                    "422": {
+                        "description": "Parâmetros inválidos",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
             },
-            "delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "clientes"
+                    "produtos"
                 ],
-                "summary": "Deletar cliente",
+                "summary": "Criar produto",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do cliente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Dados do produto",
+                        "name": "produto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CriarProdutoRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Cliente deletado",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/domain.Produto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do produto"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Erro ao deletar cliente",
+                    "400": {
+                        "description": "Requisição inválida",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
             }
         },
-        "/health": {
+        "/produtos/busca": {
             "get": {
+                "description": "Ignora acentos e tolera pequenos erros de digitação. Os resultados são ordenados por relevância, com prioridade para produtos disponíveis.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "produtos"
+                ],
+                "summary": "Buscar produtos por texto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Termo de busca",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de resultados (1 a 50, padrão 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.HealthResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Produto"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Parâmetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
             }
         },
-        "/pedidos": {
+        "/produtos/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pedidos"
+                    "produtos"
                 ],
-                "summary": "Listar pedidos",
+                "summary": "Buscar produto por ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Status do pedido",
-                        "name": "status",
-                        "in": "query"
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID do cliente",
-                        "name": "cliente_id",
-                        "in": "query"
+                        "description": "ETag já obtido; responde 304 se o produto não mudou",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Pedido"
+                            "$ref": "#/definitions/domain.Produto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do produto"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Erro ao listar pedidos",
+                    "304": {
+                        "description": "Produto não modificado",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
                     }
                 }
             },
-            "post": {
+            "put": {
+                "description": "Todos os campos são obrigatórios, inclusive disponivel; para alterar só alguns, use PATCH.",
                 "consumes": [
                     "application/json"
                 ],
@@ -301,98 +1769,169 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pedidos"
+                    "produtos"
                 ],
-                "summary": "Criar pedido",
+                "summary": "Atualizar produto",
                 "parameters": [
                     {
-                        "description": "Dados do pedido",
-                        "name": "pedido",
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do produto",
+                        "name": "produto",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CriarPedidoRequest"
+                            "$ref": "#/definitions/handlers.AtualizarProdutoRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/domain.Produto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do produto"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Erro ao criar pedido",
+                        "description": "Requisição inválida",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Produto alterado simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
-            }
-        },
-        "/pedidos/{id}": {
-            "get": {
+            },
+            "delete": {
+                "description": "O produto deixa de aparecer nas consultas, mas continua nos pedidos antigos e pode ser restaurado. Produtos em pedidos ainda não finalizados não podem ser removidos.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pedidos"
+                    "produtos"
                 ],
-                "summary": "Buscar pedido por ID",
+                "summary": "Deletar produto",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do pedido",
+                        "description": "ID do produto",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "Produto deletado",
                         "schema": {
-                            "$ref": "#/definitions/domain.Pedido"
+                            "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Pedido não encontrado",
+                        "description": "Produto não encontrado",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Produto em pedido não finalizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
-            }
-        },
-        "/pedidos/{id}/status": {
+            },
             "patch": {
+                "description": "JSON Merge Patch (RFC 7396): campos ausentes mantêm o valor atual, ex.: {\"disponivel\": false}. id, versao e as datas não podem ser alterados.",
                 "consumes": [
+                    "application/merge-patch+json",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pedidos"
+                    "produtos"
                 ],
-                "summary": "Atualizar status do pedido",
+                "summary": "Atualizar produto parcialmente",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do pedido",
+                        "description": "ID do produto",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Novo status",
-                        "name": "status",
+                        "type": "string",
+                        "description": "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "produto",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.AtualizarStatusRequest"
+                            "$ref": "#/definitions/handlers.AtualizarProdutoParcialRequest"
                         }
                     }
                 ],
@@ -400,36 +1939,170 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "$ref": "#/definitions/domain.Produto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do produto"
                             }
                         }
                     },
                     "400": {
-                        "description": "Erro ao atualizar status do pedido",
+                        "description": "Requisição inválida",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Produto alterado simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
             }
         },
-        "/produtos": {
+        "/produtos/{id}/imagem": {
+            "post": {
+                "description": "Aceita JPEG, PNG ou GIF de até IMAGE_MAX_SIZE bytes (5 MiB por padrão) e até 4096 x 4096 pixels; o formato é identificado pelo conteúdo do arquivo.\nGera as miniaturas pequena, media e grande, com até 160, 480 e 1024 pixels de largura, e substitui a imagem anterior.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "produtos"
+                ],
+                "summary": "Enviar imagem do produto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag da versão lida",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Arquivo da imagem",
+                        "name": "imagem",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Produto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do produto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Corpo não é multipart/form-data",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Produto alterado simultaneamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match não corresponde à versão atual",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "413": {
+                        "description": "Imagem maior que IMAGE_MAX_SIZE",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Imagem ausente, em formato não suportado, ilegível ou com pixels demais",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/produtos/{id}/precos": {
             "get": {
+                "description": "Do mais recente ao mais antigo, incluindo as alterações agendadas. Cada preço traz sua situação: AGENDADO, VIGENTE ou ENCERRADO.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "produtos"
                 ],
-                "summary": "Listar produtos",
+                "summary": "Histórico de preços do produto",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Categoria do produto",
-                        "name": "categoria",
-                        "in": "query"
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -438,19 +2111,26 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Produto"
+                                "$ref": "#/definitions/domain.PrecoProduto"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Erro ao listar produtos",
+                    "404": {
+                        "description": "Produto não encontrado",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
             },
             "post": {
+                "description": "O novo preço vale para os pedidos criados a partir de vigente_desde, que deve ser futuro. O preço exibido no cadastro do produto é atualizado logo depois, a cada PRICE_APPLY_INTERVAL.",
                 "consumes": [
                     "application/json"
                 ],
@@ -460,15 +2140,22 @@ const docTemplate = `{
                 "tags": [
                     "produtos"
                 ],
-                "summary": "Criar produto",
+                "summary": "Agendar preço",
                 "parameters": [
                     {
-                        "description": "Dados do produto",
-                        "name": "produto",
+                        "type": "string",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Preço e início da vigência",
+                        "name": "preco",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CriarProdutoRequest"
+                            "$ref": "#/definitions/handlers.AgendarPrecoRequest"
                         }
                     }
                 ],
@@ -476,30 +2163,45 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Produto"
+                            "$ref": "#/definitions/domain.PrecoProduto"
                         }
                     },
                     "400": {
-                        "description": "Erro ao criar produto",
+                        "description": "Requisição inválida",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "422": {
+                        "description": "Preço ou vigência inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
             }
         },
-        "/produtos/{id}": {
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/produtos/{id}/precos/{precoId}": {
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "produtos"
                 ],
-                "summary": "Atualizar produto",
+                "summary": "Cancelar preço agendado",
                 "parameters": [
                     {
                         "type": "string",
@@ -509,38 +2211,44 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Dados do produto",
-                        "name": "produto",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AtualizarProdutoRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID do preço agendado",
+                        "name": "precoId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "Agendamento cancelado",
                         "schema": {
-                            "$ref": "#/definitions/domain.Produto"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Erro ao atualizar produto",
+                    "404": {
+                        "description": "Preço agendado não encontrado ou já em vigor",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/produtos/{id}/restaurar": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "produtos"
                 ],
-                "summary": "Deletar produto",
+                "summary": "Restaurar produto",
                 "parameters": [
                     {
                         "type": "string",
@@ -551,16 +2259,34 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Produto deletado",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/domain.Produto"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Nova versão do produto"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Erro ao deletar produto",
+                    "404": {
+                        "description": "Produto não encontrado",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Produto não está removido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
+                        }
+                    },
+                    "503": {
+                        "description": "Banco de dados indisponível",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problema"
                         }
                     }
                 }
@@ -568,44 +2294,127 @@ const docTemplate = `{
         }
     },
     "definitions": {
-        "domain.Categoria": {
-            "type": "string",
-            "enum": [
-                "LANCHE",
-                "ACOMPANHAMENTO",
-                "BEBIDA",
-                "SOBREMESA"
-            ],
-            "x-enum-varnames": [
-                "CategoriaLanche",
-                "CategoriaAcompanhamento",
-                "CategoriaBebida",
-                "CategoriaSobremesa"
-            ]
+        "domain.CategoriaProduto": {
+            "type": "object",
+            "properties": {
+                "ativa": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "codigo": {
+                    "type": "string",
+                    "example": "LANCHE"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
+                },
+                "icone": {
+                    "type": "string",
+                    "example": "https://cdn.exemplo.com/icones/lanche.svg"
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "Lanches"
+                },
+                "ordem": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "traducoes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "en": "Burgers"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
+                },
+                "versao": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
         },
         "domain.Cliente": {
             "type": "object",
             "properties": {
                 "cpf": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "52998224725"
                 },
                 "created_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt só é preenchido nos clientes removidos, listados pela\nadministração.",
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "2025-02-01T09:00:00Z"
                 },
                 "email": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "maria@example.com"
                 },
                 "id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "c1552170-1909-443d-bebe-5f71e51ddd17"
                 },
                 "nome": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Maria Silva"
                 },
                 "telefone": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "11987654321"
                 },
                 "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
+                },
+                "versao": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "domain.ErroCampo": {
+            "type": "object",
+            "properties": {
+                "campo": {
+                    "type": "string",
+                    "example": "itens[0].quantidade"
+                },
+                "codigo": {
+                    "type": "string",
+                    "example": "DEVE_SER_POSITIVO"
+                },
+                "mensagem": {
+                    "type": "string",
+                    "example": "quantidade deve ser maior que zero"
+                }
+            }
+        },
+        "domain.Imagem": {
+            "type": "object",
+            "properties": {
+                "miniaturas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "pequena": "/arquivos/produtos/8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a/5c2e9f1a_pequena.jpg"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/arquivos/produtos/8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a/5c2e9f1a.jpg"
                 }
             }
         },
@@ -613,19 +2422,24 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "nome": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "X-Burger"
                 },
                 "observacao": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "sem cebola"
                 },
                 "preco": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 29.9
                 },
                 "produto_id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a"
                 },
                 "quantidade": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
@@ -633,13 +2447,16 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "cliente_id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "c1552170-1909-443d-bebe-5f71e51ddd17"
                 },
                 "created_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
                 },
                 "id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "5b7e2c1a-9f3d-4e8b-a6c2-1d0f9e8b7a64"
                 },
                 "itens": {
                     "type": "array",
@@ -648,13 +2465,59 @@ const docTemplate = `{
                     }
                 },
                 "status": {
-                    "$ref": "#/definitions/domain.StatusPedido"
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.StatusPedido"
+                        }
+                    ],
+                    "example": "RECEBIDO"
                 },
                 "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
                 },
                 "valor_total": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 59.8
+                },
+                "versao": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "domain.PrecoProduto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-20T18:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "preco": {
+                    "type": "number",
+                    "example": 31.9
+                },
+                "produto_id": {
+                    "type": "string",
+                    "example": "8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a"
+                },
+                "situacao": {
+                    "description": "Situacao não é gravada: é preenchida por ClassificarPrecos.",
+                    "type": "string",
+                    "enum": [
+                        "AGENDADO",
+                        "VIGENTE",
+                        "ENCERRADO"
+                    ],
+                    "example": "VIGENTE"
+                },
+                "vigente_desde": {
+                    "type": "string",
+                    "example": "2025-02-01T10:00:00-03:00"
                 }
             }
         },
@@ -662,28 +2525,55 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "categoria": {
-                    "$ref": "#/definitions/domain.Categoria"
+                    "type": "string",
+                    "example": "LANCHE"
                 },
                 "created_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt só é preenchido nos produtos removidos, listados pela\nadministração.",
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "2025-02-01T09:00:00Z"
                 },
                 "descricao": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Pão brioche, hambúrguer 180g e queijo"
                 },
                 "disponivel": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 },
                 "id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a"
+                },
+                "imagem": {
+                    "description": "Imagem fica ausente enquanto nenhuma imagem foi enviada.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Imagem"
+                        }
+                    ],
+                    "x-nullable": true
                 },
                 "nome": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "X-Burger"
                 },
                 "preco": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 29.9
                 },
                 "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
+                },
+                "versao": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
@@ -702,87 +2592,367 @@ const docTemplate = `{
                 "StatusFinalizado"
             ]
         },
+        "domain.StatusSaude": {
+            "type": "string",
+            "enum": [
+                "UP",
+                "DOWN"
+            ],
+            "x-enum-varnames": [
+                "SaudeOK",
+                "SaudeFalha"
+            ]
+        },
+        "domain.VerificacaoSaude": {
+            "type": "object",
+            "properties": {
+                "detalhes": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "erro": {
+                    "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.StatusSaude"
+                        }
+                    ],
+                    "example": "UP"
+                }
+            }
+        },
+        "handlers.AgendarPrecoRequest": {
+            "type": "object",
+            "required": [
+                "preco",
+                "vigente_desde"
+            ],
+            "properties": {
+                "preco": {
+                    "type": "number",
+                    "minimum": 0.01,
+                    "example": 31.9
+                },
+                "vigente_desde": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2025-02-01T10:00:00-03:00"
+                }
+            }
+        },
+        "handlers.AtualizarCategoriaParcialRequest": {
+            "type": "object",
+            "properties": {
+                "ativa": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "icone": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "https://cdn.exemplo.com/icones/cafe.svg"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Cafés"
+                },
+                "ordem": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "traducoes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "en": "Coffee"
+                    }
+                }
+            }
+        },
+        "handlers.AtualizarCategoriaRequest": {
+            "type": "object",
+            "required": [
+                "ativa",
+                "icone",
+                "nome",
+                "ordem",
+                "traducoes"
+            ],
+            "properties": {
+                "ativa": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "icone": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "https://cdn.exemplo.com/icones/cafe.svg"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Cafés"
+                },
+                "ordem": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "traducoes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "en": "Coffee"
+                    }
+                }
+            }
+        },
+        "handlers.AtualizarClienteParcialRequest": {
+            "type": "object",
+            "properties": {
+                "cpf": {
+                    "type": "string",
+                    "example": "52998224725"
+                },
+                "email": {
+                    "type": "string",
+                    "format": "email",
+                    "example": "maria@example.com"
+                },
+                "nome": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Maria Silva"
+                },
+                "telefone": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "11987654321"
+                }
+            }
+        },
         "handlers.AtualizarClienteRequest": {
             "type": "object",
+            "required": [
+                "cpf",
+                "email",
+                "nome",
+                "telefone"
+            ],
             "properties": {
                 "cpf": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "52998224725"
                 },
                 "email": {
-                    "type": "string"
+                    "type": "string",
+                    "format": "email",
+                    "example": "maria@example.com"
                 },
                 "nome": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Maria Silva"
                 },
                 "telefone": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "11987654321"
+                }
+            }
+        },
+        "handlers.AtualizarProdutoParcialRequest": {
+            "type": "object",
+            "properties": {
+                "categoria": {
+                    "type": "string",
+                    "example": "LANCHE"
+                },
+                "descricao": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Pão brioche, hambúrguer 180g e queijo"
+                },
+                "disponivel": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "nome": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "X-Burger"
+                },
+                "preco": {
+                    "type": "number",
+                    "minimum": 0.01,
+                    "example": 29.9
                 }
             }
         },
         "handlers.AtualizarProdutoRequest": {
             "type": "object",
+            "required": [
+                "categoria",
+                "descricao",
+                "disponivel",
+                "nome",
+                "preco"
+            ],
             "properties": {
                 "categoria": {
-                    "$ref": "#/definitions/domain.Categoria"
+                    "type": "string",
+                    "example": "LANCHE"
                 },
                 "descricao": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Pão brioche, hambúrguer 180g e queijo"
                 },
                 "disponivel": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 },
                 "nome": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "X-Burger"
                 },
                 "preco": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0.01,
+                    "example": 29.9
                 }
             }
         },
         "handlers.AtualizarStatusRequest": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
                 "status": {
-                    "$ref": "#/definitions/domain.StatusPedido"
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.StatusPedido"
+                        }
+                    ],
+                    "example": "EM_PREPARACAO"
+                }
+            }
+        },
+        "handlers.CriarCategoriaRequest": {
+            "type": "object",
+            "required": [
+                "codigo",
+                "nome"
+            ],
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 1,
+                    "example": "CAFE"
+                },
+                "icone": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "https://cdn.exemplo.com/icones/cafe.svg"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Cafés"
+                },
+                "ordem": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "traducoes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "en": "Coffee"
+                    }
                 }
             }
         },
         "handlers.CriarClienteRequest": {
             "type": "object",
+            "required": [
+                "cpf",
+                "email",
+                "nome",
+                "telefone"
+            ],
             "properties": {
                 "cpf": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "52998224725"
                 },
                 "email": {
-                    "type": "string"
+                    "type": "string",
+                    "format": "email",
+                    "example": "maria@example.com"
                 },
                 "nome": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Maria Silva"
                 },
                 "telefone": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "11987654321"
                 }
             }
         },
         "handlers.CriarItemPedidoRequest": {
             "type": "object",
+            "required": [
+                "produto_id",
+                "quantidade"
+            ],
             "properties": {
                 "observacao": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "sem cebola"
                 },
                 "produto_id": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a"
                 },
                 "quantidade": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
                 }
             }
         },
         "handlers.CriarPedidoRequest": {
             "type": "object",
+            "required": [
+                "itens"
+            ],
             "properties": {
                 "cliente_id": {
-                    "type": "string"
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "c1552170-1909-443d-bebe-5f71e51ddd17"
                 },
                 "itens": {
                     "type": "array",
@@ -794,18 +2964,31 @@ const docTemplate = `{
         },
         "handlers.CriarProdutoRequest": {
             "type": "object",
+            "required": [
+                "categoria",
+                "descricao",
+                "nome",
+                "preco"
+            ],
             "properties": {
                 "categoria": {
-                    "$ref": "#/definitions/domain.Categoria"
+                    "type": "string",
+                    "example": "LANCHE"
                 },
                 "descricao": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Pão brioche, hambúrguer 180g e queijo"
                 },
                 "nome": {
-                    "type": "string"
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "X-Burger"
                 },
                 "preco": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0.01,
+                    "example": 29.9
                 }
             }
         },
@@ -813,16 +2996,86 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "API está funcionando normalmente"
                 },
                 "status": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "UP"
                 },
                 "timestamp": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
                 },
                 "version": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        },
+        "handlers.Problema": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "example": "CLIENTE_NAO_ENCONTRADO"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "cliente não encontrado"
+                },
+                "erros": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ErroCampo"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/clientes/123"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f1c9a52-7d4e-4b8a-9a1e-2c6f0b7d8e91"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "handlers.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.VerificacaoSaude"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "API está funcionando normalmente"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "UP"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-01-15T12:30:00Z"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
                 }
             }
         }
@@ -832,7 +3085,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8080",
+	Host:             "",
 	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "API SOAT-FIAP",
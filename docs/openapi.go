@@ -0,0 +1,9 @@
+package docs
+
+import _ "embed"
+
+// OpenAPI é a especificação OpenAPI 3 da API, convertida de swagger.json
+// pelo comando cmd/openapi ("make docs").
+//
+//go:embed openapi.json
+var OpenAPI []byte
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"soat-fiap/internal/core/domain"
+)
+
+// folgaMultipart é o espaço reservado, além do arquivo, para os cabeçalhos
+// das partes e os demais campos do formulário.
+const folgaMultipart = 64 << 10
+
+var errArquivoMuitoGrande = errors.New("arquivo maior que o permitido")
+
+// lerArquivo lê do corpo multipart/form-data o arquivo do campo informado,
+// em memória e sem gravar as demais partes em disco como faria
+// ParseMultipartForm. Arquivos com mais de limite bytes resultam em
+// errArquivoMuitoGrande, sem que o restante do corpo seja lido.
+func lerArquivo(w http.ResponseWriter, r *http.Request, campo string, limite int64) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, limite+folgaMultipart)
+	leitor, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		parte, err := leitor.NextPart()
+		if err == io.EOF {
+			return nil, domain.NovoErroValidacao(campo, "OBRIGATORIO", "envie o arquivo no campo "+campo)
+		}
+		if err != nil {
+			return nil, arquivoMuitoGrande(err)
+		}
+		if parte.FormName() != campo {
+			continue
+		}
+
+		dados, err := io.ReadAll(io.LimitReader(parte, limite+1))
+		if err != nil {
+			return nil, arquivoMuitoGrande(err)
+		}
+		if int64(len(dados)) > limite {
+			return nil, errArquivoMuitoGrande
+		}
+		return dados, nil
+	}
+}
+
+// arquivoMuitoGrande troca o erro do MaxBytesReader por errArquivoMuitoGrande.
+func arquivoMuitoGrande(err error) error {
+	var excedido *http.MaxBytesError
+	if errors.As(err, &excedido) {
+		return errArquivoMuitoGrande
+	}
+	return err
+}
+
+// responderArquivoInvalido responde 413 para arquivos grandes demais, 422
+// para o campo ausente e 400 para corpos que não são multipart/form-data.
+func responderArquivoInvalido(w http.ResponseWriter, r *http.Request, err error, limite int64) {
+	switch {
+	case errors.Is(err, errArquivoMuitoGrande):
+		escreverProblema(w, r, Problema{
+			Type:     "about:blank",
+			Status:   http.StatusRequestEntityTooLarge,
+			Detail:   "o arquivo deve ter no máximo " + strconv.FormatInt(limite, 10) + " bytes",
+			Instance: instancia(r),
+			Codigo:   "ARQUIVO_MUITO_GRANDE",
+		})
+	case errors.Is(err, domain.ErrValidacao):
+		responderErro(w, r, err)
+	default:
+		responderRequisicaoInvalida(w, r, err)
+	}
+}
@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/internal/core/ports"
+
+	"github.com/gorilla/mux"
+)
+
+type CategoriaHandler struct {
+	categoriaService ports.CategoriaService
+}
+
+func NovoCategoriaHandler(categoriaService ports.CategoriaService) *CategoriaHandler {
+	return &CategoriaHandler{
+		categoriaService: categoriaService,
+	}
+}
+
+type CriarCategoriaRequest struct {
+	Codigo    domain.Categoria  `json:"codigo" example:"CAFE" validate:"required" minLength:"1" maxLength:"20"`
+	Nome      string            `json:"nome" example:"Cafés" validate:"required" minLength:"1" maxLength:"100"`
+	Icone     string            `json:"icone,omitempty" example:"https://cdn.exemplo.com/icones/cafe.svg" maxLength:"255"`
+	Ordem     int               `json:"ordem,omitempty" example:"5" minimum:"0"`
+	Traducoes map[string]string `json:"traducoes,omitempty" example:"en:Coffee"`
+}
+
+// CriarCategoria cadastra uma nova categoria, já ativa.
+// @Summary Criar categoria
+// @Description O código é gravado nos produtos e não pode ser alterado depois: letras maiúsculas (acentos são aceitos), dígitos e _.
+// @Tags categorias
+// @Accept json
+// @Produce json
+// @Param categoria body CriarCategoriaRequest true "Dados da categoria"
+// @Success 201 {object} domain.CategoriaProduto
+// @Header 201 {string} ETag "Versão da categoria"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 409 {object} Problema "Código já cadastrado"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /categorias [post]
+func (h *CategoriaHandler) CriarCategoria(w http.ResponseWriter, r *http.Request) {
+	var req CriarCategoriaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+
+	categoria, err := h.categoriaService.CriarCategoria(r.Context(), req.Codigo, req.Nome, req.Icone, req.Ordem, req.Traducoes)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, categoria.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(categoria)
+}
+
+// ListarCategorias retorna as categorias na ordem de exibição.
+// @Summary Listar categorias
+// @Description Ordenadas pelo campo ordem e, nos empates, pelo código. O cardápio deve usar ativa=true.
+// @Tags categorias
+// @Produce json
+// @Param ativa query bool false "Somente categorias ativas (true) ou inativas (false)"
+// @Success 200 {array} domain.CategoriaProduto
+// @Failure 422 {object} Problema "Parâmetros inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /categorias [get]
+func (h *CategoriaHandler) ListarCategorias(w http.ResponseWriter, r *http.Request) {
+	var erros domain.ErrosValidacao
+	filtro := domain.FiltroCategorias{
+		Ativa: lerBool(r.URL.Query(), "ativa", &erros),
+	}
+	if err := erros.Erro(); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	categorias, err := h.categoriaService.ListarCategorias(r.Context(), filtro)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	if categorias == nil {
+		categorias = []*domain.CategoriaProduto{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categorias)
+}
+
+// BuscarCategoria retorna uma categoria pelo código.
+// @Summary Buscar categoria
+// @Tags categorias
+// @Produce json
+// @Param codigo path string true "Código da categoria"
+// @Param If-None-Match header string false "ETag já obtido; responde 304 se a categoria não mudou"
+// @Success 200 {object} domain.CategoriaProduto
+// @Header 200 {string} ETag "Versão da categoria"
+// @Success 304 {string} string "Categoria não modificada"
+// @Failure 404 {object} Problema "Categoria não encontrada"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /categorias/{codigo} [get]
+func (h *CategoriaHandler) BuscarCategoria(w http.ResponseWriter, r *http.Request) {
+	codigo := domain.Categoria(mux.Vars(r)["codigo"])
+
+	categoria, err := h.categoriaService.BuscarCategoria(r.Context(), codigo)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	if naoModificado(w, r, categoria.Versao) {
+		return
+	}
+
+	escreverETag(w, categoria.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categoria)
+}
+
+// AtualizarCategoriaRequest é o corpo do PUT, que substitui todos os campos
+// editáveis, inclusive as traduções. Os campos são ponteiros (e traducoes,
+// um mapa que fica nil quando ausente) para que um campo ausente seja
+// rejeitado em vez de gravado vazio.
+type AtualizarCategoriaRequest struct {
+	Nome      *string           `json:"nome" example:"Cafés" validate:"required" minLength:"1" maxLength:"100"`
+	Icone     *string           `json:"icone" example:"https://cdn.exemplo.com/icones/cafe.svg" validate:"required" maxLength:"255"`
+	Ordem     *int              `json:"ordem" example:"5" validate:"required" minimum:"0"`
+	Ativa     *bool             `json:"ativa" example:"true" validate:"required"`
+	Traducoes map[string]string `json:"traducoes" example:"en:Coffee" validate:"required"`
+}
+
+// AtualizarCategoriaParcialRequest é o corpo do PATCH (JSON Merge Patch): só
+// os campos enviados são alterados. Em traducoes, cada idioma enviado é
+// incluído ou substituído, e um idioma com null é removido.
+type AtualizarCategoriaParcialRequest struct {
+	Nome      *string           `json:"nome,omitempty" example:"Cafés" minLength:"1" maxLength:"100"`
+	Icone     *string           `json:"icone,omitempty" example:"https://cdn.exemplo.com/icones/cafe.svg" maxLength:"255"`
+	Ordem     *int              `json:"ordem,omitempty" example:"5" minimum:"0"`
+	Ativa     *bool             `json:"ativa,omitempty" example:"false"`
+	Traducoes map[string]string `json:"traducoes,omitempty" example:"en:Coffee"`
+}
+
+// novoAtualizarCategoriaRequest parte de um mapa de traduções não nil, para
+// que o patch que não menciona traducoes mantenha as atuais (mesmo vazias).
+func novoAtualizarCategoriaRequest(categoria *domain.CategoriaProduto) AtualizarCategoriaRequest {
+	traducoes := maps.Clone(categoria.Traducoes)
+	if traducoes == nil {
+		traducoes = map[string]string{}
+	}
+	return AtualizarCategoriaRequest{
+		Nome:      &categoria.Nome,
+		Icone:     &categoria.Icone,
+		Ordem:     &categoria.Ordem,
+		Ativa:     &categoria.Ativa,
+		Traducoes: traducoes,
+	}
+}
+
+// Validar exige todos os campos; os valores são conferidos pelo domínio.
+func (req AtualizarCategoriaRequest) Validar() error {
+	var erros domain.ErrosValidacao
+	if req.Nome == nil {
+		erros.Adicionar("nome", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Icone == nil {
+		erros.Adicionar("icone", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Ordem == nil {
+		erros.Adicionar("ordem", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Ativa == nil {
+		erros.Adicionar("ativa", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Traducoes == nil {
+		erros.Adicionar("traducoes", "OBRIGATORIO", "campo obrigatório")
+	}
+	return erros.Erro()
+}
+
+func (req AtualizarCategoriaRequest) aplicar(categoria *domain.CategoriaProduto) {
+	categoria.Nome = *req.Nome
+	categoria.Icone = *req.Icone
+	categoria.Ordem = *req.Ordem
+	categoria.Ativa = *req.Ativa
+	categoria.Traducoes = req.Traducoes
+}
+
+// AtualizarCategoria substitui os dados de uma categoria existente.
+// @Summary Atualizar categoria
+// @Description Todos os campos são obrigatórios; para alterar só alguns, use PATCH. O código não pode ser alterado. Desativar a categoria a esconde do cardápio e impede novos produtos nela, sem alterar os produtos existentes.
+// @Tags categorias
+// @Accept json
+// @Produce json
+// @Param codigo path string true "Código da categoria"
+// @Param If-Match header string false "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true"
+// @Param categoria body AtualizarCategoriaRequest true "Dados da categoria"
+// @Success 200 {object} domain.CategoriaProduto
+// @Header 200 {string} ETag "Nova versão da categoria"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Categoria não encontrada"
+// @Failure 409 {object} Problema "Categoria alterada simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 428 {object} Problema "If-Match obrigatório"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /categorias/{codigo} [put]
+func (h *CategoriaHandler) AtualizarCategoria(w http.ResponseWriter, r *http.Request) {
+	codigo := domain.Categoria(mux.Vars(r)["codigo"])
+
+	var req AtualizarCategoriaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+	if err := req.Validar(); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	categoriaExistente, err := h.categoriaService.BuscarCategoria(r.Context(), codigo)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	versao, err := versaoIfMatch(r, func() (int64, error) { return categoriaExistente.Versao, nil })
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	if versao != 0 {
+		categoriaExistente.Versao = versao
+	}
+	req.aplicar(categoriaExistente)
+
+	if err := h.categoriaService.AtualizarCategoria(r.Context(), categoriaExistente); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, categoriaExistente.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categoriaExistente)
+}
+
+// AtualizarCategoriaParcial altera só os campos enviados de uma categoria.
+// @Summary Atualizar categoria parcialmente
+// @Description JSON Merge Patch (RFC 7396): campos ausentes mantêm o valor atual; em traducoes, um idioma com null é removido. codigo, versao e as datas não podem ser alterados.
+// @Tags categorias
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param codigo path string true "Código da categoria"
+// @Param If-Match header string false "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true"
+// @Param categoria body AtualizarCategoriaParcialRequest true "Campos a alterar"
+// @Success 200 {object} domain.CategoriaProduto
+// @Header 200 {string} ETag "Nova versão da categoria"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Categoria não encontrada"
+// @Failure 409 {object} Problema "Categoria alterada simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 428 {object} Problema "If-Match obrigatório"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /categorias/{codigo} [patch]
+func (h *CategoriaHandler) AtualizarCategoriaParcial(w http.ResponseWriter, r *http.Request) {
+	codigo := domain.Categoria(mux.Vars(r)["codigo"])
+
+	categoriaExistente, err := h.categoriaService.BuscarCategoria(r.Context(), codigo)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	versao, err := versaoIfMatch(r, func() (int64, error) { return categoriaExistente.Versao, nil })
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	if versao != 0 {
+		categoriaExistente.Versao = versao
+	}
+
+	mesclado, err := mesclarPatch(novoAtualizarCategoriaRequest(categoriaExistente), r.Body)
+	if err != nil {
+		responderPatchInvalido(w, r, err)
+		return
+	}
+	var parcial AtualizarCategoriaParcialRequest
+	if err := json.Unmarshal(mesclado, &parcial); err != nil {
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+	req := AtualizarCategoriaRequest(parcial)
+	if req.Traducoes == nil {
+		req.Traducoes = map[string]string{}
+	}
+	if err := req.Validar(); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	req.aplicar(categoriaExistente)
+
+	if err := h.categoriaService.AtualizarCategoria(r.Context(), categoriaExistente); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, categoriaExistente.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categoriaExistente)
+}
+
+// DeletarCategoria remove uma categoria sem produtos.
+// @Summary Deletar categoria
+// @Description Categorias com produtos, mesmo removidos, não podem ser apagadas; desative-as com PATCH {"ativa": false}.
+// @Tags categorias
+// @Produce json
+// @Param codigo path string true "Código da categoria"
+// @Success 204 {string} string "Categoria deletada"
+// @Failure 404 {object} Problema "Categoria não encontrada"
+// @Failure 409 {object} Problema "Categoria com produtos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /categorias/{codigo} [delete]
+func (h *CategoriaHandler) DeletarCategoria(w http.ResponseWriter, r *http.Request) {
+	codigo := domain.Categoria(mux.Vars(r)["codigo"])
+
+	if err := h.categoriaService.DeletarCategoria(r.Context(), codigo); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"regexp"
 
+	"soat-fiap/internal/core/domain"
 	"soat-fiap/internal/core/ports"
 
 	"github.com/gorilla/mux"
@@ -21,10 +22,10 @@ func NovoClienteHandler(clienteService ports.ClienteService) *ClienteHandler {
 }
 
 type CriarClienteRequest struct {
-	Nome     string `json:"nome"`
-	CPF      string `json:"cpf"`
-	Email    string `json:"email"`
-	Telefone string `json:"telefone"`
+	Nome     string `json:"nome" example:"Maria Silva" validate:"required" minLength:"1"`
+	CPF      string `json:"cpf" example:"52998224725" validate:"required"`
+	Email    string `json:"email" example:"maria@example.com" validate:"required" format:"email"`
+	Telefone string `json:"telefone" example:"11987654321" validate:"required" minLength:"1"`
 }
 
 // CriarCliente cria um novo cliente.
@@ -34,21 +35,26 @@ type CriarClienteRequest struct {
 // @Produce json
 // @Param cliente body CriarClienteRequest true "Dados do cliente"
 // @Success 201 {object} domain.Cliente
-// @Failure 400 {string} string "Erro ao criar cliente"
+// @Header 201 {string} ETag "Versão do cliente"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 409 {object} Problema "CPF já cadastrado"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /clientes [post]
 func (h *ClienteHandler) CriarCliente(w http.ResponseWriter, r *http.Request) {
 	var req CriarClienteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Erro ao decodificar requisição: "+err.Error(), http.StatusBadRequest)
+		responderRequisicaoInvalida(w, r, err)
 		return
 	}
 
 	cliente, err := h.clienteService.CriarCliente(r.Context(), req.Nome, req.CPF, req.Email, req.Telefone)
 	if err != nil {
-		http.Error(w, "Erro ao criar cliente: "+err.Error(), http.StatusBadRequest)
+		responderErro(w, r, err)
 		return
 	}
 
+	escreverETag(w, cliente.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(cliente)
@@ -59,8 +65,12 @@ func (h *ClienteHandler) CriarCliente(w http.ResponseWriter, r *http.Request) {
 // @Tags clientes
 // @Produce json
 // @Param id path string true "ID do cliente"
+// @Param If-None-Match header string false "ETag já obtido; responde 304 se o cliente não mudou"
 // @Success 200 {object} domain.Cliente
-// @Failure 404 {string} string "Cliente não encontrado"
+// @Header 200 {string} ETag "Versão do cliente"
+// @Success 304 {string} string "Cliente não modificado"
+// @Failure 404 {object} Problema "Cliente não encontrado"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /clientes/{id} [get]
 func (h *ClienteHandler) BuscarClientePorID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -68,15 +78,15 @@ func (h *ClienteHandler) BuscarClientePorID(w http.ResponseWriter, r *http.Reque
 
 	cliente, err := h.clienteService.BuscarClientePorID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Erro ao buscar cliente: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
 
-	if cliente == nil {
-		http.Error(w, "Cliente não encontrado", http.StatusNotFound)
+	if naoModificado(w, r, cliente.Versao) {
 		return
 	}
 
+	escreverETag(w, cliente.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(cliente)
 }
@@ -86,8 +96,13 @@ func (h *ClienteHandler) BuscarClientePorID(w http.ResponseWriter, r *http.Reque
 // @Tags clientes
 // @Produce json
 // @Param cpf path string true "CPF do cliente"
+// @Param If-None-Match header string false "ETag já obtido; responde 304 se o cliente não mudou"
 // @Success 200 {object} domain.Cliente
-// @Failure 404 {string} string "Cliente não encontrado"
+// @Header 200 {string} ETag "Versão do cliente"
+// @Success 304 {string} string "Cliente não modificado"
+// @Failure 404 {object} Problema "Cliente não encontrado"
+// @Failure 422 {object} Problema "CPF inválido"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /clientes/cpf/{cpf} [get]
 func (h *ClienteHandler) BuscarClientePorCPF(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -98,53 +113,155 @@ func (h *ClienteHandler) BuscarClientePorCPF(w http.ResponseWriter, r *http.Requ
 
 	cliente, err := h.clienteService.BuscarClientePorCPF(r.Context(), cpf)
 	if err != nil {
-		http.Error(w, "Erro ao buscar cliente: "+err.Error(), http.StatusBadRequest)
+		responderErro(w, r, err)
 		return
 	}
 
-	if cliente == nil {
-		http.Error(w, "Cliente não encontrado", http.StatusNotFound)
+	if naoModificado(w, r, cliente.Versao) {
 		return
 	}
 
+	escreverETag(w, cliente.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(cliente)
 }
 
-// ListarClientes retorna todos os clientes.
+// ListarClientes retorna os clientes paginados por cursor.
 // @Summary Listar clientes
+// @Description A próxima página é indicada pelos cabeçalhos Link (rel="next") e X-Next-Cursor.
 // @Tags clientes
 // @Produce json
+// @Param nome query string false "Trecho do nome do cliente"
+// @Param limit query int false "Quantidade máxima de itens (1 a 200, padrão 50)"
+// @Param cursor query string false "Cursor retornado pela página anterior"
+// @Param ordenar query string false "Campo de ordenação; prefixo - para decrescente" Enums(nome, -nome, created_at, -created_at)
 // @Success 200 {array} domain.Cliente
-// @Failure 500 {string} string "Erro ao listar clientes"
+// @Header 200 {string} Link "Link para a próxima página"
+// @Header 200 {string} X-Next-Cursor "Cursor da próxima página"
+// @Failure 422 {object} Problema "Parâmetros inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /clientes [get]
 func (h *ClienteHandler) ListarClientes(w http.ResponseWriter, r *http.Request) {
-	clientes, err := h.clienteService.ListarClientes(r.Context())
+	h.listar(w, r, false)
+}
+
+// ListarClientesRemovidos lista os clientes removidos, com os mesmos filtros e
+// a mesma paginação da listagem comum.
+// @Summary Listar clientes removidos
+// @Description Uso administrativo: os clientes removidos não aparecem nas demais consultas, mas continuam nos pedidos antigos. Podem ser reativados com POST /clientes/{id}/restaurar.
+// @Tags admin
+// @Produce json
+// @Param nome query string false "Trecho do nome do cliente"
+// @Param limit query int false "Quantidade máxima de itens (1 a 200, padrão 50)"
+// @Param cursor query string false "Cursor retornado pela página anterior"
+// @Param ordenar query string false "Campo de ordenação; prefixo - para decrescente" Enums(nome, -nome, created_at, -created_at)
+// @Success 200 {array} domain.Cliente
+// @Header 200 {string} Link "Link para a próxima página"
+// @Header 200 {string} X-Next-Cursor "Cursor da próxima página"
+// @Failure 422 {object} Problema "Parâmetros inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /admin/clientes/removidos [get]
+func (h *ClienteHandler) ListarClientesRemovidos(w http.ResponseWriter, r *http.Request) {
+	h.listar(w, r, true)
+}
+
+func (h *ClienteHandler) listar(w http.ResponseWriter, r *http.Request, removidos bool) {
+	q := r.URL.Query()
+	var erros domain.ErrosValidacao
+	filtro := domain.FiltroClientes{
+		Paginacao: lerPaginacao(q, &erros),
+		Nome:      q.Get("nome"),
+		Removidos: removidos,
+	}
+	if err := erros.Erro(); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	pagina, err := h.clienteService.ListarClientes(r.Context(), filtro)
 	if err != nil {
-		http.Error(w, "Erro ao listar clientes: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
 
+	escreverPaginacao(w, r, pagina.ProximoCursor)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(clientes)
+	json.NewEncoder(w).Encode(pagina.Itens)
 }
 
+// AtualizarClienteRequest é o corpo do PUT, que substitui todos os campos
+// editáveis. Os campos são ponteiros para que um campo ausente seja
+// rejeitado em vez de gravado vazio.
 type AtualizarClienteRequest struct {
-	Nome     string `json:"nome"`
-	CPF      string `json:"cpf"`
-	Email    string `json:"email"`
-	Telefone string `json:"telefone"`
+	Nome     *string `json:"nome" example:"Maria Silva" validate:"required" minLength:"1"`
+	CPF      *string `json:"cpf" example:"52998224725" validate:"required"`
+	Email    *string `json:"email" example:"maria@example.com" validate:"required" format:"email"`
+	Telefone *string `json:"telefone" example:"11987654321" validate:"required" minLength:"1"`
+}
+
+// AtualizarClienteParcialRequest é o corpo do PATCH (JSON Merge Patch): só
+// os campos enviados são alterados.
+type AtualizarClienteParcialRequest struct {
+	Nome     *string `json:"nome,omitempty" example:"Maria Silva" minLength:"1"`
+	CPF      *string `json:"cpf,omitempty" example:"52998224725"`
+	Email    *string `json:"email,omitempty" example:"maria@example.com" format:"email"`
+	Telefone *string `json:"telefone,omitempty" example:"11987654321" minLength:"1"`
+}
+
+func novoAtualizarClienteRequest(cliente *domain.Cliente) AtualizarClienteRequest {
+	return AtualizarClienteRequest{
+		Nome:     &cliente.Nome,
+		CPF:      &cliente.CPF,
+		Email:    &cliente.Email,
+		Telefone: &cliente.Telefone,
+	}
+}
+
+// Validar exige todos os campos; os valores são conferidos pelo domínio.
+func (req AtualizarClienteRequest) Validar() error {
+	var erros domain.ErrosValidacao
+	obrigatorios := []struct {
+		campo string
+		valor *string
+	}{
+		{"nome", req.Nome},
+		{"cpf", req.CPF},
+		{"email", req.Email},
+		{"telefone", req.Telefone},
+	}
+	for _, o := range obrigatorios {
+		if o.valor == nil {
+			erros.Adicionar(o.campo, "OBRIGATORIO", "campo obrigatório")
+		}
+	}
+	return erros.Erro()
+}
+
+func (req AtualizarClienteRequest) aplicar(cliente *domain.Cliente) {
+	cliente.Nome = *req.Nome
+	cliente.CPF = *req.CPF
+	cliente.Email = *req.Email
+	cliente.Telefone = *req.Telefone
 }
 
-// AtualizarCliente atualiza um cliente existente.
+// AtualizarCliente substitui os dados de um cliente existente.
 // @Summary Atualizar cliente
+// @Description Todos os campos são obrigatórios; para alterar só alguns, use PATCH.
 // @Tags clientes
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do cliente"
+// @Param If-Match header string false "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true"
 // @Param cliente body AtualizarClienteRequest true "Dados do cliente"
 // @Success 200 {object} domain.Cliente
-// @Failure 400 {string} string "Erro ao atualizar cliente"
+// @Header 200 {string} ETag "Nova versão do cliente"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Cliente não encontrado"
+// @Failure 409 {object} Problema "CPF já cadastrado ou cliente alterado simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 428 {object} Problema "If-Match obrigatório"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /clientes/{id} [put]
 func (h *ClienteHandler) AtualizarCliente(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -152,42 +269,113 @@ func (h *ClienteHandler) AtualizarCliente(w http.ResponseWriter, r *http.Request
 
 	var req AtualizarClienteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Erro ao decodificar requisição: "+err.Error(), http.StatusBadRequest)
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+	if err := req.Validar(); err != nil {
+		responderErro(w, r, err)
 		return
 	}
 
 	clienteExistente, err := h.clienteService.BuscarClientePorID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Erro ao buscar cliente: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
-
-	if clienteExistente == nil {
-		http.Error(w, "Cliente não encontrado", http.StatusNotFound)
+	versao, err := versaoIfMatch(r, func() (int64, error) { return clienteExistente.Versao, nil })
+	if err != nil {
+		responderErro(w, r, err)
 		return
 	}
-	clienteExistente.Nome = req.Nome
-	clienteExistente.CPF = req.CPF
-	clienteExistente.Email = req.Email
-	clienteExistente.Telefone = req.Telefone
+	if versao != 0 {
+		clienteExistente.Versao = versao
+	}
+	req.aplicar(clienteExistente)
 
 	err = h.clienteService.AtualizarCliente(r.Context(), clienteExistente)
 	if err != nil {
-		http.Error(w, "Erro ao atualizar cliente: "+err.Error(), http.StatusBadRequest)
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, clienteExistente.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clienteExistente)
+}
+
+// AtualizarClienteParcial altera só os campos enviados de um cliente.
+// @Summary Atualizar cliente parcialmente
+// @Description JSON Merge Patch (RFC 7396): campos ausentes mantêm o valor atual. id, versao e as datas não podem ser alterados.
+// @Tags clientes
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path string true "ID do cliente"
+// @Param If-Match header string false "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true"
+// @Param cliente body AtualizarClienteParcialRequest true "Campos a alterar"
+// @Success 200 {object} domain.Cliente
+// @Header 200 {string} ETag "Nova versão do cliente"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Cliente não encontrado"
+// @Failure 409 {object} Problema "CPF já cadastrado ou cliente alterado simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 428 {object} Problema "If-Match obrigatório"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /clientes/{id} [patch]
+func (h *ClienteHandler) AtualizarClienteParcial(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	clienteExistente, err := h.clienteService.BuscarClientePorID(r.Context(), id)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	versao, err := versaoIfMatch(r, func() (int64, error) { return clienteExistente.Versao, nil })
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	if versao != 0 {
+		clienteExistente.Versao = versao
+	}
+
+	mesclado, err := mesclarPatch(novoAtualizarClienteRequest(clienteExistente), r.Body)
+	if err != nil {
+		responderPatchInvalido(w, r, err)
+		return
+	}
+	var parcial AtualizarClienteParcialRequest
+	if err := json.Unmarshal(mesclado, &parcial); err != nil {
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+	req := AtualizarClienteRequest(parcial)
+	if err := req.Validar(); err != nil {
+		responderErro(w, r, err)
 		return
 	}
+	req.aplicar(clienteExistente)
 
+	if err := h.clienteService.AtualizarCliente(r.Context(), clienteExistente); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, clienteExistente.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(clienteExistente)
 }
 
-// DeletarCliente remove um cliente pelo ID.
+// DeletarCliente remove logicamente um cliente pelo ID.
 // @Summary Deletar cliente
+// @Description O cliente deixa de aparecer nas consultas, mas continua nos pedidos antigos e pode ser restaurado. O CPF continua reservado para ele.
 // @Tags clientes
 // @Produce json
 // @Param id path string true "ID do cliente"
 // @Success 204 {string} string "Cliente deletado"
-// @Failure 500 {string} string "Erro ao deletar cliente"
+// @Failure 404 {object} Problema "Cliente não encontrado"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /clientes/{id} [delete]
 func (h *ClienteHandler) DeletarCliente(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -195,9 +383,35 @@ func (h *ClienteHandler) DeletarCliente(w http.ResponseWriter, r *http.Request)
 
 	err := h.clienteService.DeletarCliente(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Erro ao deletar cliente: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestaurarCliente desfaz a remoção de um cliente.
+// @Summary Restaurar cliente
+// @Tags clientes
+// @Produce json
+// @Param id path string true "ID do cliente"
+// @Success 200 {object} domain.Cliente
+// @Header 200 {string} ETag "Nova versão do cliente"
+// @Failure 404 {object} Problema "Cliente não encontrado"
+// @Failure 409 {object} Problema "Cliente não está removido"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /clientes/{id}/restaurar [post]
+func (h *ClienteHandler) RestaurarCliente(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	cliente, err := h.clienteService.RestaurarCliente(r.Context(), id)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, cliente.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cliente)
+}
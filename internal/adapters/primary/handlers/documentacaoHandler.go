@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DocumentacaoHandler serve a especificação OpenAPI 3 embutida no binário,
+// completando na hora o que só se sabe em execução: a versão da API e o
+// endereço pelo qual o cliente a acessou.
+type DocumentacaoHandler struct {
+	spec     map[string]any
+	versao   string
+	basePath string
+}
+
+func NovoDocumentacaoHandler(spec []byte, versao string) (*DocumentacaoHandler, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("especificação OpenAPI inválida: %w", err)
+	}
+
+	basePath := ""
+	if servidores, ok := doc["servers"].([]any); ok && len(servidores) > 0 {
+		if servidor, ok := servidores[0].(map[string]any); ok {
+			basePath, _ = servidor["url"].(string)
+		}
+	}
+
+	return &DocumentacaoHandler{spec: doc, versao: versao, basePath: basePath}, nil
+}
+
+// OpenAPI retorna a especificação OpenAPI 3 da API.
+func (h *DocumentacaoHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc := make(map[string]any, len(h.spec))
+	for chave, valor := range h.spec {
+		doc[chave] = valor
+	}
+
+	info := map[string]any{}
+	if original, ok := h.spec["info"].(map[string]any); ok {
+		for chave, valor := range original {
+			info[chave] = valor
+		}
+	}
+	info["version"] = h.versao
+	doc["info"] = info
+	doc["servers"] = []map[string]any{{"url": origem(r) + h.basePath}}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(doc)
+}
+
+// origem devolve esquema e host usados pelo cliente, respeitando os
+// cabeçalhos de um proxy reverso à frente da API.
+func origem(r *http.Request) string {
+	esquema := "http"
+	if r.TLS != nil {
+		esquema = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		esquema = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+
+	host := r.Host
+	if encaminhado := r.Header.Get("X-Forwarded-Host"); encaminhado != "" {
+		host = strings.TrimSpace(strings.Split(encaminhado, ",")[0])
+	}
+
+	return esquema + "://" + host
+}
@@ -3,35 +3,68 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/internal/core/ports"
 )
 
 type HealthResponse struct {
-	Status    string    `json:"status"`
-	Timestamp time.Time `json:"timestamp"`
-	Message   string    `json:"message"`
-	Version   string    `json:"version"`
+	Status    string    `json:"status" example:"UP"`
+	Timestamp time.Time `json:"timestamp" example:"2025-01-15T12:30:00Z"`
+	Message   string    `json:"message" example:"API está funcionando normalmente"`
+	Version   string    `json:"version" example:"1.0.0"`
+}
+
+// ReadinessResponse traz o resultado de cada verificador, indexado pelo nome.
+type ReadinessResponse struct {
+	HealthResponse
+	Checks map[string]domain.VerificacaoSaude `json:"checks,omitempty"`
 }
 
 type HealthHandler struct {
-	version string
+	version       string
+	verificadores []ports.VerificadorSaude
+	encerrando    atomic.Bool
 }
 
-func NovoHealthHandler(version string) *HealthHandler {
+func NovoHealthHandler(version string, verificadores ...ports.VerificadorSaude) *HealthHandler {
 	return &HealthHandler{
-		version: version,
+		version:       version,
+		verificadores: verificadores,
 	}
 }
 
+// Encerrar faz a prontidão falhar a partir de agora, para que o orquestrador
+// pare de enviar tráfego enquanto o servidor termina as requisições em curso.
+func (h *HealthHandler) Encerrar() {
+	h.encerrando.Store(true)
+}
+
 // HealthCheck retorna o status de saúde da API.
 // @Summary Health check
+// @Description Equivalente a /health/live, mantido por compatibilidade.
 // @Tags health
 // @Produce json
 // @Success 200 {object} HealthResponse
 // @Router /health [get]
 func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	h.Liveness(w, r)
+}
+
+// Liveness indica apenas que o processo está respondendo. Não consulta
+// dependências, para que uma queda do banco não faça o orquestrador
+// reiniciar todas as réplicas.
+// @Summary Liveness probe
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /health/live [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
 	response := HealthResponse{
-		Status:    "UP",
+		Status:    string(domain.SaudeOK),
 		Timestamp: time.Now(),
 		Message:   "API está funcionando normalmente",
 		Version:   h.version,
@@ -41,3 +74,65 @@ func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+// Readiness executa os verificadores em paralelo e responde 503 se algum
+// falhar ou se a API estiver encerrando.
+// @Summary Readiness probe
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse "Alguma dependência indisponível ou API encerrando"
+// @Router /health/ready [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	response := ReadinessResponse{
+		HealthResponse: HealthResponse{
+			Status:    string(domain.SaudeOK),
+			Timestamp: time.Now(),
+			Message:   "API pronta para receber requisições",
+			Version:   h.version,
+		},
+	}
+
+	if h.encerrando.Load() {
+		response.Status = string(domain.SaudeFalha)
+		response.Message = "API encerrando"
+	} else {
+		response.Checks = h.verificar(r)
+		for _, resultado := range response.Checks {
+			if resultado.Status != domain.SaudeOK {
+				response.Status = string(domain.SaudeFalha)
+				response.Message = "Uma ou mais dependências estão indisponíveis"
+			}
+		}
+	}
+
+	status := http.StatusOK
+	if response.Status != string(domain.SaudeOK) {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *HealthHandler) verificar(r *http.Request) map[string]domain.VerificacaoSaude {
+	resultados := make(map[string]domain.VerificacaoSaude, len(h.verificadores))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, verificador := range h.verificadores {
+		wg.Add(1)
+		go func(v ports.VerificadorSaude) {
+			defer wg.Done()
+			resultado := v.Verificar(r.Context())
+			mu.Lock()
+			resultados[v.Nome()] = resultado
+			mu.Unlock()
+		}(verificador)
+	}
+	wg.Wait()
+
+	return resultados
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+
+	"soat-fiap/internal/core/domain"
+)
+
+var errPatchNaoObjeto = errors.New("o patch deve ser um objeto JSON")
+
+// mesclarPatch aplica um JSON Merge Patch (RFC 7396) sobre a representação
+// JSON de original e devolve o resultado, para ser decodificado em um valor
+// novo do mesmo tipo. Campos do patch com null são removidos, os ausentes
+// mantêm o valor original. Campos que original não tem (inclusive os somente
+// leitura, como id e versao) são rejeitados como CAMPO_DESCONHECIDO.
+func mesclarPatch(original any, corpo io.Reader) ([]byte, error) {
+	var patch any
+	decoder := json.NewDecoder(corpo)
+	decoder.UseNumber()
+	if err := decoder.Decode(&patch); err != nil {
+		return nil, err
+	}
+	campos, ok := patch.(map[string]any)
+	if !ok {
+		return nil, errPatchNaoObjeto
+	}
+
+	atual, err := json.Marshal(original)
+	if err != nil {
+		return nil, err
+	}
+	var alvo map[string]any
+	decoder = json.NewDecoder(bytes.NewReader(atual))
+	decoder.UseNumber()
+	if err := decoder.Decode(&alvo); err != nil {
+		return nil, err
+	}
+
+	var desconhecidos []string
+	for campo := range campos {
+		if _, ok := alvo[campo]; !ok {
+			desconhecidos = append(desconhecidos, campo)
+		}
+	}
+	if len(desconhecidos) > 0 {
+		sort.Strings(desconhecidos)
+		var erros domain.ErrosValidacao
+		for _, campo := range desconhecidos {
+			erros.Adicionar(campo, "CAMPO_DESCONHECIDO", "campo não pode ser alterado")
+		}
+		return nil, erros.Erro()
+	}
+
+	return json.Marshal(mesclar(alvo, campos))
+}
+
+// mesclar segue o algoritmo MergePatch da RFC 7396, seção 2.
+func mesclar(alvo any, patch any) any {
+	campos, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	resultado, ok := alvo.(map[string]any)
+	if !ok {
+		resultado = make(map[string]any)
+	}
+	for campo, valor := range campos {
+		if valor == nil {
+			delete(resultado, campo)
+			continue
+		}
+		resultado[campo] = mesclar(resultado[campo], valor)
+	}
+	return resultado
+}
+
+// responderPatchInvalido separa os campos rejeitados pelo patch (422) do
+// corpo que não é um objeto JSON (400).
+func responderPatchInvalido(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, domain.ErrValidacao) {
+		responderErro(w, r, err)
+		return
+	}
+	responderRequisicaoInvalida(w, r, err)
+}
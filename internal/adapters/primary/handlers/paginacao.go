@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"soat-fiap/internal/core/domain"
+)
+
+// lerPaginacao lê limit, cursor e ordenar da query string. ordenar aceita o
+// prefixo "-" para ordem decrescente, ex.: ordenar=-preco.
+func lerPaginacao(q url.Values, erros *domain.ErrosValidacao) domain.Paginacao {
+	p := domain.Paginacao{Cursor: q.Get("cursor")}
+
+	if limite := q.Get("limit"); limite != "" {
+		valor, err := strconv.Atoi(limite)
+		if err != nil || valor <= 0 {
+			erros.Adicionar("limit", "INVALIDO", "limit deve ser um inteiro positivo")
+		}
+		p.Limite = valor
+	}
+
+	if ordenar := q.Get("ordenar"); ordenar != "" {
+		p.Ordenacao = strings.TrimPrefix(ordenar, "-")
+		p.Decrescente = strings.HasPrefix(ordenar, "-")
+	}
+
+	return p
+}
+
+// lerLista aceita o parâmetro repetido ou separado por vírgulas.
+func lerLista(q url.Values, nome string) []string {
+	var valores []string
+	for _, valor := range q[nome] {
+		for _, item := range strings.Split(valor, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				valores = append(valores, item)
+			}
+		}
+	}
+	return valores
+}
+
+func lerDecimal(q url.Values, nome string, erros *domain.ErrosValidacao) *float64 {
+	texto := q.Get(nome)
+	if texto == "" {
+		return nil
+	}
+	valor, err := strconv.ParseFloat(texto, 64)
+	if err != nil {
+		erros.Adicionar(nome, "INVALIDO", nome+" deve ser um número")
+		return nil
+	}
+	return &valor
+}
+
+func lerBool(q url.Values, nome string, erros *domain.ErrosValidacao) *bool {
+	texto := q.Get(nome)
+	if texto == "" {
+		return nil
+	}
+	valor, err := strconv.ParseBool(texto)
+	if err != nil {
+		erros.Adicionar(nome, "INVALIDO", nome+" deve ser true ou false")
+		return nil
+	}
+	return &valor
+}
+
+// lerData aceita RFC 3339 ou apenas a data (AAAA-MM-DD, meia-noite UTC).
+func lerData(q url.Values, nome string, erros *domain.ErrosValidacao) *time.Time {
+	texto := q.Get(nome)
+	if texto == "" {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if valor, err := time.Parse(layout, texto); err == nil {
+			return &valor
+		}
+	}
+	erros.Adicionar(nome, "INVALIDO", nome+" deve estar no formato AAAA-MM-DD ou RFC 3339")
+	return nil
+}
+
+// escreverPaginacao expõe o cursor da próxima página nos cabeçalhos Link
+// (rel="next") e X-Next-Cursor. O corpo continua sendo a lista de itens.
+func escreverPaginacao(w http.ResponseWriter, r *http.Request, proximoCursor string) {
+	if proximoCursor == "" {
+		return
+	}
+
+	proxima := *r.URL
+	q := proxima.Query()
+	q.Set("cursor", proximoCursor)
+	proxima.RawQuery = q.Encode()
+
+	w.Header().Set("Link", "<"+proxima.RequestURI()+`>; rel="next"`)
+	w.Header().Set("X-Next-Cursor", proximoCursor)
+}
@@ -20,18 +20,39 @@ func NovoPedidoHandler(pedidoService ports.PedidoService) *PedidoHandler {
 }
 
 type CriarPedidoRequest struct {
-	ClienteID *string                  `json:"cliente_id,omitempty"`
-	Itens     []CriarItemPedidoRequest `json:"itens"`
+	ClienteID *string                  `json:"cliente_id,omitempty" example:"c1552170-1909-443d-bebe-5f71e51ddd17" extensions:"x-nullable"`
+	Itens     []CriarItemPedidoRequest `json:"itens" validate:"required" minItems:"1"`
 }
 
 type CriarItemPedidoRequest struct {
-	ProdutoID  string `json:"produto_id"`
-	Quantidade int    `json:"quantidade"`
-	Observacao string `json:"observacao,omitempty"`
+	ProdutoID  string `json:"produto_id" example:"8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a" validate:"required" minLength:"1"`
+	Quantidade int    `json:"quantidade" example:"2" validate:"required" minimum:"1"`
+	Observacao string `json:"observacao,omitempty" example:"sem cebola"`
+}
+
+// Validar verifica o formato do payload antes de consultar os produtos,
+// devolvendo todas as violações com o índice do item (ex.: itens[2].quantidade).
+func (req CriarPedidoRequest) Validar() error {
+	var erros domain.ErrosValidacao
+
+	if len(req.Itens) == 0 {
+		erros.Adicionar("itens", "OBRIGATORIO", "pedido deve ter pelo menos um item")
+	}
+
+	for i, item := range req.Itens {
+		if item.ProdutoID == "" {
+			erros.Adicionar(domain.CampoItem(i, "produto_id"), "OBRIGATORIO", "produto ID não pode ser vazio")
+		}
+		if item.Quantidade <= 0 {
+			erros.Adicionar(domain.CampoItem(i, "quantidade"), "DEVE_SER_POSITIVO", "quantidade deve ser maior que zero")
+		}
+	}
+
+	return erros.Erro()
 }
 
 type AtualizarStatusRequest struct {
-	Status domain.StatusPedido `json:"status"`
+	Status domain.StatusPedido `json:"status" example:"EM_PREPARACAO" validate:"required"`
 }
 
 // FakeCheckout cria um novo pedido (checkout fake para testes ou integração).
@@ -41,12 +62,19 @@ type AtualizarStatusRequest struct {
 // @Produce json
 // @Param pedido body CriarPedidoRequest true "Dados do pedido"
 // @Success 201 {object} map[string]interface{}
-// @Failure 400 {string} string "Erro ao criar pedido"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 422 {object} Problema "Itens inválidos, inexistentes ou indisponíveis"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /pedidos [post]
 func (h *PedidoHandler) FakeCheckout(w http.ResponseWriter, r *http.Request) {
 	var req CriarPedidoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Erro ao decodificar requisição: "+err.Error(), http.StatusBadRequest)
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+
+	if err := req.Validar(); err != nil {
+		responderErro(w, r, err)
 		return
 	}
 
@@ -61,7 +89,7 @@ func (h *PedidoHandler) FakeCheckout(w http.ResponseWriter, r *http.Request) {
 
 	pedido, err := h.pedidoService.CriarPedido(r.Context(), req.ClienteID, itens)
 	if err != nil {
-		http.Error(w, "Erro ao criar pedido: "+err.Error(), http.StatusBadRequest)
+		responderErro(w, r, err)
 		return
 	}
 
@@ -73,37 +101,51 @@ func (h *PedidoHandler) FakeCheckout(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// ListarPedidos retorna todos os pedidos, podendo filtrar por status ou cliente.
+// ListarPedidos retorna os pedidos paginados por cursor. Os filtros podem ser combinados.
 // @Summary Listar pedidos
+// @Description Sem ordenação explícita, retorna os mais recentes primeiro ou, com filtro de status, os mais antigos primeiro.
+// @Description A próxima página é indicada pelos cabeçalhos Link (rel="next") e X-Next-Cursor.
 // @Tags pedidos
 // @Produce json
-// @Param status query string false "Status do pedido"
+// @Param status query []string false "Status do pedido (repetido ou separado por vírgulas)" collectionFormat(csv)
 // @Param cliente_id query string false "ID do cliente"
+// @Param de query string false "Criados a partir de (AAAA-MM-DD ou RFC 3339, inclusivo)"
+// @Param ate query string false "Criados antes de (AAAA-MM-DD ou RFC 3339, exclusivo)"
+// @Param limit query int false "Quantidade máxima de itens (1 a 200, padrão 50)"
+// @Param cursor query string false "Cursor retornado pela página anterior"
+// @Param ordenar query string false "Campo de ordenação; prefixo - para decrescente" Enums(created_at, -created_at, updated_at, -updated_at, valor_total, -valor_total)
 // @Success 200 {array} domain.Pedido
-// @Failure 500 {string} string "Erro ao listar pedidos"
+// @Header 200 {string} Link "Link para a próxima página"
+// @Header 200 {string} X-Next-Cursor "Cursor da próxima página"
+// @Failure 422 {object} Problema "Parâmetros inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /pedidos [get]
 func (h *PedidoHandler) ListarPedidos(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status")
-	clienteID := r.URL.Query().Get("cliente_id")
-
-	var pedidos []*domain.Pedido
-	var err error
-
-	if status != "" {
-		pedidos, err = h.pedidoService.ListarPedidosPorStatus(r.Context(), domain.StatusPedido(status))
-	} else if clienteID != "" {
-		pedidos, err = h.pedidoService.ListarPedidosPorCliente(r.Context(), clienteID)
-	} else {
-		pedidos, err = h.pedidoService.ListarPedidos(r.Context())
+	q := r.URL.Query()
+	var erros domain.ErrosValidacao
+	filtro := domain.FiltroPedidos{
+		Paginacao: lerPaginacao(q, &erros),
+		ClienteID: q.Get("cliente_id"),
+		De:        lerData(q, "de", &erros),
+		Ate:       lerData(q, "ate", &erros),
+	}
+	for _, status := range lerLista(q, "status") {
+		filtro.Status = append(filtro.Status, domain.StatusPedido(status))
+	}
+	if err := erros.Erro(); err != nil {
+		responderErro(w, r, err)
+		return
 	}
 
+	pagina, err := h.pedidoService.ListarPedidos(r.Context(), filtro)
 	if err != nil {
-		http.Error(w, "Erro ao listar pedidos: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
 
+	escreverPaginacao(w, r, pagina.ProximoCursor)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(pedidos)
+	json.NewEncoder(w).Encode(pagina.Itens)
 }
 
 // BuscarPedidoPorID retorna um pedido pelo ID.
@@ -111,8 +153,12 @@ func (h *PedidoHandler) ListarPedidos(w http.ResponseWriter, r *http.Request) {
 // @Tags pedidos
 // @Produce json
 // @Param id path string true "ID do pedido"
+// @Param If-None-Match header string false "ETag já obtido; responde 304 se o pedido não mudou"
 // @Success 200 {object} domain.Pedido
-// @Failure 404 {string} string "Pedido não encontrado"
+// @Header 200 {string} ETag "Versão do pedido"
+// @Success 304 {string} string "Pedido não modificado"
+// @Failure 404 {object} Problema "Pedido não encontrado"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /pedidos/{id} [get]
 func (h *PedidoHandler) BuscarPedidoPorID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -120,15 +166,14 @@ func (h *PedidoHandler) BuscarPedidoPorID(w http.ResponseWriter, r *http.Request
 
 	pedido, err := h.pedidoService.BuscarPedidoPorID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Erro ao buscar pedido: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
-
-	if pedido == nil {
-		http.Error(w, "Pedido não encontrado", http.StatusNotFound)
+	if naoModificado(w, r, pedido.Versao) {
 		return
 	}
 
+	escreverETag(w, pedido.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(pedido)
 }
@@ -139,9 +184,17 @@ func (h *PedidoHandler) BuscarPedidoPorID(w http.ResponseWriter, r *http.Request
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do pedido"
+// @Param If-Match header string false "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true"
 // @Param status body AtualizarStatusRequest true "Novo status"
 // @Success 200 {object} map[string]string
-// @Failure 400 {string} string "Erro ao atualizar status do pedido"
+// @Header 200 {string} ETag "Nova versão do pedido"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Pedido não encontrado"
+// @Failure 409 {object} Problema "Pedido alterado simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 422 {object} Problema "Status inválido"
+// @Failure 428 {object} Problema "If-Match obrigatório"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /pedidos/{id}/status [patch]
 func (h *PedidoHandler) AtualizarStatusPedido(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -149,16 +202,29 @@ func (h *PedidoHandler) AtualizarStatusPedido(w http.ResponseWriter, r *http.Req
 
 	var req AtualizarStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Erro ao decodificar requisição: "+err.Error(), http.StatusBadRequest)
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+
+	versao, err := versaoIfMatch(r, func() (int64, error) {
+		pedido, err := h.pedidoService.BuscarPedidoPorID(r.Context(), id)
+		if err != nil {
+			return 0, err
+		}
+		return pedido.Versao, nil
+	})
+	if err != nil {
+		responderErro(w, r, err)
 		return
 	}
 
-	err := h.pedidoService.AtualizarStatusPedido(r.Context(), id, req.Status)
+	pedido, err := h.pedidoService.AtualizarStatusPedido(r.Context(), id, req.Status, versao)
 	if err != nil {
-		http.Error(w, "Erro ao atualizar status do pedido: "+err.Error(), http.StatusBadRequest)
+		responderErro(w, r, err)
 		return
 	}
 
+	escreverETag(w, pedido.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
 		"message": "Status do pedido atualizado com sucesso",
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/pkg/correlacao"
+	"soat-fiap/pkg/logger"
+
+	"github.com/gorilla/mux"
+)
+
+// Problema é o corpo de erro no formato RFC 7807 (application/problem+json).
+// Codigo é estável e deve ser usado pelos clientes no lugar de Detail.
+// RequestID é o mesmo do cabeçalho X-Request-ID e deve acompanhar relatos de
+// erro para que sejam localizados nos logs.
+type Problema struct {
+	Type      string             `json:"type" example:"about:blank"`
+	Title     string             `json:"title" example:"Not Found"`
+	Status    int                `json:"status" example:"404"`
+	Detail    string             `json:"detail,omitempty" example:"cliente não encontrado"`
+	Instance  string             `json:"instance,omitempty" example:"/api/v1/clientes/123"`
+	Codigo    string             `json:"codigo" example:"CLIENTE_NAO_ENCONTRADO"`
+	Erros     []domain.ErroCampo `json:"erros,omitempty"`
+	RequestID string             `json:"request_id,omitempty" example:"3f1c9a52-7d4e-4b8a-9a1e-2c6f0b7d8e91"`
+}
+
+// responderErro traduz um erro retornado pelos serviços em uma resposta
+// problem+json. Erros não classificados viram 500 sem expor detalhes internos.
+func responderErro(w http.ResponseWriter, r *http.Request, err error) {
+	problema := Problema{
+		Type:     "about:blank",
+		Instance: instancia(r),
+	}
+
+	var erroDominio *domain.Erro
+	if errors.As(err, &erroDominio) {
+		problema.Codigo = erroDominio.Codigo
+		problema.Detail = erroDominio.Mensagem
+		problema.Erros = erroDominio.Campos
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrValidacao):
+		problema.Status = http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrNaoEncontrado):
+		problema.Status = http.StatusNotFound
+	case errors.Is(err, domain.ErrConflito):
+		problema.Status = http.StatusConflict
+	case errors.Is(err, domain.ErrPrecondicao):
+		// Sem If-Match a versão conferida foi a lida pelo próprio serviço, e
+		// a alteração simultânea é um conflito comum, não uma pré-condição
+		// do cliente.
+		if r.Header.Get("If-Match") != "" {
+			problema.Status = http.StatusPreconditionFailed
+		} else {
+			problema.Status = http.StatusConflict
+		}
+	case errors.Is(err, domain.ErrIndisponivel):
+		logger.DoContexto(r.Context()).Warn("dependência indisponível", "erro", err)
+		problema.Status = http.StatusServiceUnavailable
+		w.Header().Set("Retry-After", "5")
+	default:
+		logger.DoContexto(r.Context()).Error("erro interno", "erro", err)
+		problema.Status = http.StatusInternalServerError
+		problema.Codigo = "ERRO_INTERNO"
+		problema.Detail = "erro interno do servidor"
+		problema.Erros = nil
+	}
+
+	if problema.Codigo == "" {
+		problema.Codigo = codigoPadrao(problema.Status)
+	}
+
+	escreverProblema(w, r, problema)
+}
+
+// responderRequisicaoInvalida é usado quando o corpo da requisição não pode
+// ser decodificado. Campos com tipo errado são reportados como erro de campo.
+func responderRequisicaoInvalida(w http.ResponseWriter, r *http.Request, err error) {
+	var erroTipo *json.UnmarshalTypeError
+	if errors.As(err, &erroTipo) && erroTipo.Field != "" {
+		var erros domain.ErrosValidacao
+		erros.Adicionar(erroTipo.Field, "TIPO_INVALIDO", "valor deve ser do tipo "+erroTipo.Type.String())
+		responderErro(w, r, erros.Erro())
+		return
+	}
+
+	escreverProblema(w, r, Problema{
+		Type:     "about:blank",
+		Status:   http.StatusBadRequest,
+		Detail:   "corpo da requisição inválido: " + err.Error(),
+		Instance: instancia(r),
+		Codigo:   "REQUISICAO_INVALIDA",
+	})
+}
+
+// ResponderErro e ResponderRequisicaoInvalida expõem as respostas de erro
+// aos middlewares que rejeitam a requisição antes do handler, para que o
+// cliente receba o mesmo formato.
+func ResponderErro(w http.ResponseWriter, r *http.Request, err error) {
+	responderErro(w, r, err)
+}
+
+func ResponderRequisicaoInvalida(w http.ResponseWriter, r *http.Request, err error) {
+	responderRequisicaoInvalida(w, r, err)
+}
+
+// ResponderIfMatchObrigatorio rejeita com 428 uma alteração sem If-Match
+// quando o cabeçalho é exigido.
+func ResponderIfMatchObrigatorio(w http.ResponseWriter, r *http.Request) {
+	escreverProblema(w, r, Problema{
+		Type:     "about:blank",
+		Status:   http.StatusPreconditionRequired,
+		Detail:   "envie o cabeçalho If-Match com o ETag da versão lida",
+		Instance: instancia(r),
+		Codigo:   "IF_MATCH_OBRIGATORIO",
+	})
+}
+
+// instancia é o caminho da requisição com o CPF mascarado, para que o corpo
+// do erro (e quem o registra em log) não repita o dado pessoal de rotas como
+// /clientes/cpf/{cpf}.
+func instancia(r *http.Request) string {
+	cpf, ok := mux.Vars(r)["cpf"]
+	if !ok || cpf == "" {
+		return r.URL.Path
+	}
+	inicio := strings.LastIndex(r.URL.Path, cpf)
+	if inicio < 0 {
+		// O CPF não aparece literalmente no caminho (ex.: codificado); o
+		// template da rota identifica o recurso sem expô-lo.
+		template, _ := mux.CurrentRoute(r).GetPathTemplate()
+		return template
+	}
+	return r.URL.Path[:inicio] + logger.MascararCPF(cpf) + r.URL.Path[inicio+len(cpf):]
+}
+
+func escreverProblema(w http.ResponseWriter, r *http.Request, problema Problema) {
+	problema.Title = http.StatusText(problema.Status)
+	problema.RequestID = correlacao.DoContexto(r.Context())
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problema.Status)
+	json.NewEncoder(w).Encode(problema)
+}
+
+func codigoPadrao(status int) string {
+	switch status {
+	case http.StatusUnprocessableEntity:
+		return "VALIDACAO"
+	case http.StatusNotFound:
+		return "NAO_ENCONTRADO"
+	case http.StatusConflict:
+		return "CONFLITO"
+	case http.StatusPreconditionFailed:
+		return "PRECONDICAO"
+	case http.StatusServiceUnavailable:
+		return "SERVICO_INDISPONIVEL"
+	default:
+		return "ERRO_INTERNO"
+	}
+}
@@ -3,6 +3,9 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
+
 	"soat-fiap/internal/core/domain"
 	"soat-fiap/internal/core/ports"
 
@@ -11,19 +14,22 @@ import (
 
 type ProdutoHandler struct {
 	produtoService ports.ProdutoService
+	// tamanhoMaximoImagem é o limite, em bytes, das imagens enviadas.
+	tamanhoMaximoImagem int64
 }
 
-func NovoProdutoHandler(produtoService ports.ProdutoService) *ProdutoHandler {
+func NovoProdutoHandler(produtoService ports.ProdutoService, tamanhoMaximoImagem int64) *ProdutoHandler {
 	return &ProdutoHandler{
-		produtoService: produtoService,
+		produtoService:      produtoService,
+		tamanhoMaximoImagem: tamanhoMaximoImagem,
 	}
 }
 
 type CriarProdutoRequest struct {
-	Nome      string           `json:"nome"`
-	Descricao string           `json:"descricao"`
-	Preco     float64          `json:"preco"`
-	Categoria domain.Categoria `json:"categoria"`
+	Nome      string           `json:"nome" example:"X-Burger" validate:"required" minLength:"1"`
+	Descricao string           `json:"descricao" example:"Pão brioche, hambúrguer 180g e queijo" validate:"required" minLength:"1"`
+	Preco     float64          `json:"preco" example:"29.9" validate:"required" minimum:"0.01"`
+	Categoria domain.Categoria `json:"categoria" example:"LANCHE" validate:"required"`
 }
 
 // CriarProduto cria um novo produto.
@@ -33,66 +39,159 @@ type CriarProdutoRequest struct {
 // @Produce json
 // @Param produto body CriarProdutoRequest true "Dados do produto"
 // @Success 201 {object} domain.Produto
-// @Failure 400 {string} string "Erro ao criar produto"
+// @Header 201 {string} ETag "Versão do produto"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /produtos [post]
 func (h *ProdutoHandler) CriarProduto(w http.ResponseWriter, r *http.Request) {
 	var req CriarProdutoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Erro ao decodificar requisição: "+err.Error(), http.StatusBadRequest)
+		responderRequisicaoInvalida(w, r, err)
 		return
 	}
 
 	produto, err := h.produtoService.CriarProduto(r.Context(), req.Nome, req.Descricao, req.Preco, req.Categoria)
 	if err != nil {
-		http.Error(w, "Erro ao criar produto: "+err.Error(), http.StatusBadRequest)
+		responderErro(w, r, err)
 		return
 	}
 
+	escreverETag(w, produto.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(produto)
 }
 
+// BuscarProdutoPorID busca um produto pelo ID.
+// @Summary Buscar produto por ID
+// @Tags produtos
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param If-None-Match header string false "ETag já obtido; responde 304 se o produto não mudou"
+// @Success 200 {object} domain.Produto
+// @Header 200 {string} ETag "Versão do produto"
+// @Success 304 {string} string "Produto não modificado"
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/{id} [get]
 func (h *ProdutoHandler) BuscarProdutoPorID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
 	produto, err := h.produtoService.BuscarProdutoPorID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Erro ao buscar produto: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
-
-	if produto == nil {
-		http.Error(w, "Produto não encontrado", http.StatusNotFound)
+	if naoModificado(w, r, produto.Versao) {
 		return
 	}
 
+	escreverETag(w, produto.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(produto)
 }
 
-// ListarProdutos retorna todos os produtos, podendo filtrar por categoria.
+// ListarProdutos retorna os produtos paginados por cursor, com filtros opcionais.
 // @Summary Listar produtos
+// @Description A próxima página é indicada pelos cabeçalhos Link (rel="next") e X-Next-Cursor.
 // @Tags produtos
 // @Produce json
 // @Param categoria query string false "Categoria do produto"
+// @Param preco_min query number false "Preço mínimo (inclusivo)"
+// @Param preco_max query number false "Preço máximo (inclusivo)"
+// @Param disponivel query bool false "Somente produtos disponíveis (true) ou indisponíveis (false)"
+// @Param limit query int false "Quantidade máxima de itens (1 a 200, padrão 50)"
+// @Param cursor query string false "Cursor retornado pela página anterior"
+// @Param ordenar query string false "Campo de ordenação; prefixo - para decrescente" Enums(nome, -nome, preco, -preco, created_at, -created_at)
 // @Success 200 {array} domain.Produto
-// @Failure 500 {string} string "Erro ao listar produtos"
+// @Header 200 {string} Link "Link para a próxima página"
+// @Header 200 {string} X-Next-Cursor "Cursor da próxima página"
+// @Failure 422 {object} Problema "Parâmetros inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /produtos [get]
 func (h *ProdutoHandler) ListarProdutos(w http.ResponseWriter, r *http.Request) {
-	categoria := r.URL.Query().Get("categoria")
-	var produtos []*domain.Produto
-	var err error
+	h.listar(w, r, false)
+}
+
+// ListarProdutosRemovidos lista os produtos removidos, com os mesmos filtros e
+// a mesma paginação da listagem comum.
+// @Summary Listar produtos removidos
+// @Description Uso administrativo: os produtos removidos não aparecem nas demais consultas, mas continuam nos pedidos antigos. Podem ser reativados com POST /produtos/{id}/restaurar.
+// @Tags admin
+// @Produce json
+// @Param categoria query string false "Categoria do produto"
+// @Param preco_min query number false "Preço mínimo (inclusivo)"
+// @Param preco_max query number false "Preço máximo (inclusivo)"
+// @Param disponivel query bool false "Somente produtos disponíveis (true) ou indisponíveis (false)"
+// @Param limit query int false "Quantidade máxima de itens (1 a 200, padrão 50)"
+// @Param cursor query string false "Cursor retornado pela página anterior"
+// @Param ordenar query string false "Campo de ordenação; prefixo - para decrescente" Enums(nome, -nome, preco, -preco, created_at, -created_at)
+// @Success 200 {array} domain.Produto
+// @Header 200 {string} Link "Link para a próxima página"
+// @Header 200 {string} X-Next-Cursor "Cursor da próxima página"
+// @Failure 422 {object} Problema "Parâmetros inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /admin/produtos/removidos [get]
+func (h *ProdutoHandler) ListarProdutosRemovidos(w http.ResponseWriter, r *http.Request) {
+	h.listar(w, r, true)
+}
+
+func (h *ProdutoHandler) listar(w http.ResponseWriter, r *http.Request, removidos bool) {
+	q := r.URL.Query()
+	var erros domain.ErrosValidacao
+	filtro := domain.FiltroProdutos{
+		Paginacao:  lerPaginacao(q, &erros),
+		Categoria:  domain.Categoria(q.Get("categoria")),
+		PrecoMin:   lerDecimal(q, "preco_min", &erros),
+		PrecoMax:   lerDecimal(q, "preco_max", &erros),
+		Disponivel: lerBool(q, "disponivel", &erros),
+		Removidos:  removidos,
+	}
+	if err := erros.Erro(); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	pagina, err := h.produtoService.ListarProdutos(r.Context(), filtro)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverPaginacao(w, r, pagina.ProximoCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pagina.Itens)
+}
+
+// BuscarProdutos faz uma busca textual por nome e descrição.
+// @Summary Buscar produtos por texto
+// @Description Ignora acentos e tolera pequenos erros de digitação. Os resultados são ordenados por relevância, com prioridade para produtos disponíveis.
+// @Tags produtos
+// @Produce json
+// @Param q query string true "Termo de busca"
+// @Param limit query int false "Quantidade máxima de resultados (1 a 50, padrão 20)"
+// @Success 200 {array} domain.Produto
+// @Failure 422 {object} Problema "Parâmetros inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/busca [get]
+func (h *ProdutoHandler) BuscarProdutos(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
 
-	if categoria != "" {
-		produtos, err = h.produtoService.ListarProdutosPorCategoria(r.Context(), domain.Categoria(categoria))
-	} else {
-		produtos, err = h.produtoService.ListarProdutos(r.Context())
+	limite := 0
+	if texto := q.Get("limit"); texto != "" {
+		valor, err := strconv.Atoi(texto)
+		if err != nil {
+			responderErro(w, r, domain.NovoErroValidacao("limit", "INVALIDO", "limit deve ser um inteiro positivo"))
+			return
+		}
+		limite = valor
 	}
 
+	produtos, err := h.produtoService.BuscarProdutos(r.Context(), q.Get("q"), limite)
 	if err != nil {
-		http.Error(w, "Erro ao listar produtos: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
 
@@ -100,23 +199,84 @@ func (h *ProdutoHandler) ListarProdutos(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(produtos)
 }
 
+// AtualizarProdutoRequest é o corpo do PUT, que substitui todos os campos
+// editáveis. Os campos são ponteiros para que um campo ausente seja
+// rejeitado em vez de gravado vazio (ou, em disponivel, como false).
 type AtualizarProdutoRequest struct {
-	Nome       string           `json:"nome"`
-	Descricao  string           `json:"descricao"`
-	Preco      float64          `json:"preco"`
-	Categoria  domain.Categoria `json:"categoria"`
-	Disponivel bool             `json:"disponivel"`
+	Nome       *string           `json:"nome" example:"X-Burger" validate:"required" minLength:"1"`
+	Descricao  *string           `json:"descricao" example:"Pão brioche, hambúrguer 180g e queijo" validate:"required" minLength:"1"`
+	Preco      *float64          `json:"preco" example:"29.9" validate:"required" minimum:"0.01"`
+	Categoria  *domain.Categoria `json:"categoria" example:"LANCHE" validate:"required"`
+	Disponivel *bool             `json:"disponivel" example:"true" validate:"required"`
+}
+
+// AtualizarProdutoParcialRequest é o corpo do PATCH (JSON Merge Patch): só
+// os campos enviados são alterados, ex.: {"disponivel": false}.
+type AtualizarProdutoParcialRequest struct {
+	Nome       *string           `json:"nome,omitempty" example:"X-Burger" minLength:"1"`
+	Descricao  *string           `json:"descricao,omitempty" example:"Pão brioche, hambúrguer 180g e queijo" minLength:"1"`
+	Preco      *float64          `json:"preco,omitempty" example:"29.9" minimum:"0.01"`
+	Categoria  *domain.Categoria `json:"categoria,omitempty" example:"LANCHE"`
+	Disponivel *bool             `json:"disponivel,omitempty" example:"false"`
+}
+
+func novoAtualizarProdutoRequest(produto *domain.Produto) AtualizarProdutoRequest {
+	return AtualizarProdutoRequest{
+		Nome:       &produto.Nome,
+		Descricao:  &produto.Descricao,
+		Preco:      &produto.Preco,
+		Categoria:  &produto.Categoria,
+		Disponivel: &produto.Disponivel,
+	}
 }
 
-// AtualizarProduto atualiza um produto existente.
+// Validar exige todos os campos; os valores são conferidos pelo domínio.
+func (req AtualizarProdutoRequest) Validar() error {
+	var erros domain.ErrosValidacao
+	if req.Nome == nil {
+		erros.Adicionar("nome", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Descricao == nil {
+		erros.Adicionar("descricao", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Preco == nil {
+		erros.Adicionar("preco", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Categoria == nil {
+		erros.Adicionar("categoria", "OBRIGATORIO", "campo obrigatório")
+	}
+	if req.Disponivel == nil {
+		erros.Adicionar("disponivel", "OBRIGATORIO", "campo obrigatório")
+	}
+	return erros.Erro()
+}
+
+func (req AtualizarProdutoRequest) aplicar(produto *domain.Produto) {
+	produto.Nome = *req.Nome
+	produto.Descricao = *req.Descricao
+	produto.Preco = *req.Preco
+	produto.Categoria = *req.Categoria
+	produto.Disponivel = *req.Disponivel
+}
+
+// AtualizarProduto substitui os dados de um produto existente.
 // @Summary Atualizar produto
+// @Description Todos os campos são obrigatórios, inclusive disponivel; para alterar só alguns, use PATCH.
 // @Tags produtos
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do produto"
+// @Param If-Match header string false "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true"
 // @Param produto body AtualizarProdutoRequest true "Dados do produto"
 // @Success 200 {object} domain.Produto
-// @Failure 400 {string} string "Erro ao atualizar produto"
+// @Header 200 {string} ETag "Nova versão do produto"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 409 {object} Problema "Produto alterado simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 428 {object} Problema "If-Match obrigatório"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /produtos/{id} [put]
 func (h *ProdutoHandler) AtualizarProduto(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -124,44 +284,115 @@ func (h *ProdutoHandler) AtualizarProduto(w http.ResponseWriter, r *http.Request
 
 	var req AtualizarProdutoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Erro ao decodificar requisição: "+err.Error(), http.StatusBadRequest)
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+	if err := req.Validar(); err != nil {
+		responderErro(w, r, err)
 		return
 	}
 
 	produtoExistente, err := h.produtoService.BuscarProdutoPorID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Erro ao buscar produto: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
 		return
 	}
-
-	if produtoExistente == nil {
-		http.Error(w, "Produto não encontrado", http.StatusNotFound)
+	versao, err := versaoIfMatch(r, func() (int64, error) { return produtoExistente.Versao, nil })
+	if err != nil {
+		responderErro(w, r, err)
 		return
 	}
+	if versao != 0 {
+		produtoExistente.Versao = versao
+	}
 
-	produtoExistente.Nome = req.Nome
-	produtoExistente.Descricao = req.Descricao
-	produtoExistente.Preco = req.Preco
-	produtoExistente.Categoria = req.Categoria
-	produtoExistente.Disponivel = req.Disponivel
+	req.aplicar(produtoExistente)
 
 	err = h.produtoService.AtualizarProduto(r.Context(), produtoExistente)
 	if err != nil {
-		http.Error(w, "Erro ao atualizar produto: "+err.Error(), http.StatusBadRequest)
+		responderErro(w, r, err)
 		return
 	}
 
+	escreverETag(w, produtoExistente.Versao)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(produtoExistente)
 }
 
-// DeletarProduto remove um produto pelo ID.
+// AtualizarProdutoParcial altera só os campos enviados de um produto.
+// @Summary Atualizar produto parcialmente
+// @Description JSON Merge Patch (RFC 7396): campos ausentes mantêm o valor atual, ex.: {"disponivel": false}. id, versao e as datas não podem ser alterados.
+// @Tags produtos
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param If-Match header string false "ETag da versão lida; obrigatório com IF_MATCH_REQUIRED=true"
+// @Param produto body AtualizarProdutoParcialRequest true "Campos a alterar"
+// @Success 200 {object} domain.Produto
+// @Header 200 {string} ETag "Nova versão do produto"
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 409 {object} Problema "Produto alterado simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 422 {object} Problema "Campos inválidos"
+// @Failure 428 {object} Problema "If-Match obrigatório"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/{id} [patch]
+func (h *ProdutoHandler) AtualizarProdutoParcial(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	produtoExistente, err := h.produtoService.BuscarProdutoPorID(r.Context(), id)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	versao, err := versaoIfMatch(r, func() (int64, error) { return produtoExistente.Versao, nil })
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	if versao != 0 {
+		produtoExistente.Versao = versao
+	}
+
+	mesclado, err := mesclarPatch(novoAtualizarProdutoRequest(produtoExistente), r.Body)
+	if err != nil {
+		responderPatchInvalido(w, r, err)
+		return
+	}
+	var parcial AtualizarProdutoParcialRequest
+	if err := json.Unmarshal(mesclado, &parcial); err != nil {
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+	req := AtualizarProdutoRequest(parcial)
+	if err := req.Validar(); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+	req.aplicar(produtoExistente)
+
+	if err := h.produtoService.AtualizarProduto(r.Context(), produtoExistente); err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, produtoExistente.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(produtoExistente)
+}
+
+// DeletarProduto remove logicamente um produto pelo ID.
 // @Summary Deletar produto
+// @Description O produto deixa de aparecer nas consultas, mas continua nos pedidos antigos e pode ser restaurado. Produtos em pedidos ainda não finalizados não podem ser removidos.
 // @Tags produtos
 // @Produce json
 // @Param id path string true "ID do produto"
 // @Success 204 {string} string "Produto deletado"
-// @Failure 500 {string} string "Erro ao deletar produto"
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 409 {object} Problema "Produto em pedido não finalizado"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
 // @Router /produtos/{id} [delete]
 func (h *ProdutoHandler) DeletarProduto(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -169,7 +400,178 @@ func (h *ProdutoHandler) DeletarProduto(w http.ResponseWriter, r *http.Request)
 
 	err := h.produtoService.DeletarProduto(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Erro ao deletar produto: "+err.Error(), http.StatusInternalServerError)
+		responderErro(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestaurarProduto desfaz a remoção de um produto.
+// @Summary Restaurar produto
+// @Tags produtos
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Success 200 {object} domain.Produto
+// @Header 200 {string} ETag "Nova versão do produto"
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 409 {object} Problema "Produto não está removido"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/{id}/restaurar [post]
+func (h *ProdutoHandler) RestaurarProduto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	produto, err := h.produtoService.RestaurarProduto(r.Context(), id)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, produto.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(produto)
+}
+
+// EnviarImagem recebe a imagem de um produto.
+// @Summary Enviar imagem do produto
+// @Description Aceita JPEG, PNG ou GIF de até IMAGE_MAX_SIZE bytes (5 MiB por padrão) e até 4096 x 4096 pixels; o formato é identificado pelo conteúdo do arquivo.
+// @Description Gera as miniaturas pequena, media e grande, com até 160, 480 e 1024 pixels de largura, e substitui a imagem anterior.
+// @Tags produtos
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param If-Match header string false "ETag da versão lida"
+// @Param imagem formData file true "Arquivo da imagem"
+// @Success 200 {object} domain.Produto
+// @Header 200 {string} ETag "Nova versão do produto"
+// @Failure 400 {object} Problema "Corpo não é multipart/form-data"
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 409 {object} Problema "Produto alterado simultaneamente"
+// @Failure 412 {object} Problema "If-Match não corresponde à versão atual"
+// @Failure 413 {object} Problema "Imagem maior que IMAGE_MAX_SIZE"
+// @Failure 422 {object} Problema "Imagem ausente, em formato não suportado, ilegível ou com pixels demais"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/{id}/imagem [post]
+func (h *ProdutoHandler) EnviarImagem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	versao, err := versaoIfMatch(r, func() (int64, error) {
+		produto, err := h.produtoService.BuscarProdutoPorID(r.Context(), id)
+		if err != nil {
+			return 0, err
+		}
+		return produto.Versao, nil
+	})
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	conteudo, err := lerArquivo(w, r, "imagem", h.tamanhoMaximoImagem)
+	if err != nil {
+		responderArquivoInvalido(w, r, err, h.tamanhoMaximoImagem)
+		return
+	}
+
+	produto, err := h.produtoService.EnviarImagem(r.Context(), id, conteudo, versao)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	escreverETag(w, produto.Versao)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(produto)
+}
+
+type AgendarPrecoRequest struct {
+	Preco        float64   `json:"preco" example:"31.9" validate:"required" minimum:"0.01"`
+	VigenteDesde time.Time `json:"vigente_desde" example:"2025-02-01T10:00:00-03:00" validate:"required" format:"date-time"`
+}
+
+// HistoricoPrecos lista os preços de um produto.
+// @Summary Histórico de preços do produto
+// @Description Do mais recente ao mais antigo, incluindo as alterações agendadas. Cada preço traz sua situação: AGENDADO, VIGENTE ou ENCERRADO.
+// @Tags produtos
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Success 200 {array} domain.PrecoProduto
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/{id}/precos [get]
+func (h *ProdutoHandler) HistoricoPrecos(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	precos, err := h.produtoService.HistoricoPrecos(r.Context(), id)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(precos)
+}
+
+// AgendarPreco agenda uma alteração de preço.
+// @Summary Agendar preço
+// @Description O novo preço vale para os pedidos criados a partir de vigente_desde, que deve ser futuro. O preço exibido no cadastro do produto é atualizado logo depois, a cada PRICE_APPLY_INTERVAL.
+// @Tags produtos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param preco body AgendarPrecoRequest true "Preço e início da vigência"
+// @Success 201 {object} domain.PrecoProduto
+// @Failure 400 {object} Problema "Requisição inválida"
+// @Failure 404 {object} Problema "Produto não encontrado"
+// @Failure 422 {object} Problema "Preço ou vigência inválidos"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/{id}/precos [post]
+func (h *ProdutoHandler) AgendarPreco(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req AgendarPrecoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responderRequisicaoInvalida(w, r, err)
+		return
+	}
+
+	preco, err := h.produtoService.AgendarPreco(r.Context(), id, req.Preco, req.VigenteDesde)
+	if err != nil {
+		responderErro(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(preco)
+}
+
+// CancelarPrecoAgendado cancela uma alteração de preço que ainda não entrou em vigor.
+// @Summary Cancelar preço agendado
+// @Tags produtos
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param precoId path int true "ID do preço agendado"
+// @Success 204 {string} string "Agendamento cancelado"
+// @Failure 404 {object} Problema "Preço agendado não encontrado ou já em vigor"
+// @Failure 503 {object} Problema "Banco de dados indisponível"
+// @Router /produtos/{id}/precos/{precoId} [delete]
+func (h *ProdutoHandler) CancelarPrecoAgendado(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	precoID, err := strconv.ParseInt(vars["precoId"], 10, 64)
+	if err != nil {
+		responderErro(w, r, domain.ErrPrecoAgendadoNaoEncontrado)
+		return
+	}
+
+	if err := h.produtoService.CancelarPrecoAgendado(r.Context(), id, precoID); err != nil {
+		responderErro(w, r, err)
 		return
 	}
 
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"soat-fiap/internal/core/domain"
+)
+
+// etag monta o ETag de um recurso a partir da sua versão, ex.: "3".
+func etag(versao int64) string {
+	return `"` + strconv.FormatInt(versao, 10) + `"`
+}
+
+func escreverETag(w http.ResponseWriter, versao int64) {
+	w.Header().Set("ETag", etag(versao))
+}
+
+// versaoIfMatch lê a versão esperada do cabeçalho If-Match. Sem cabeçalho ou
+// com "*" devolve zero, que dispensa a conferência. O cabeçalho pode listar
+// vários ETags (RFC 9110); com mais de um, atual informa a versão do recurso
+// e ela é a esperada se estiver na lista. Valores que não são ETags desta API
+// (inclusive ETags fracos, que If-Match não aceita) não correspondem a
+// nenhuma versão; sem nenhuma correspondente o resultado é
+// ErrVersaoDesatualizada.
+func versaoIfMatch(r *http.Request, atual func() (int64, error)) (int64, error) {
+	cabecalho := strings.TrimSpace(r.Header.Get("If-Match"))
+	if cabecalho == "" || cabecalho == "*" {
+		return 0, nil
+	}
+
+	var versoes []int64
+	for _, valor := range strings.Split(cabecalho, ",") {
+		valor = strings.TrimSpace(valor)
+		versao, err := strconv.ParseInt(strings.Trim(valor, `"`), 10, 64)
+		if err == nil && versao > 0 && valor == etag(versao) {
+			versoes = append(versoes, versao)
+		}
+	}
+
+	switch len(versoes) {
+	case 0:
+		return 0, domain.ErrVersaoDesatualizada
+	case 1:
+		return versoes[0], nil
+	}
+
+	versao, err := atual()
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Contains(versoes, versao) {
+		return 0, domain.ErrVersaoDesatualizada
+	}
+	return versao, nil
+}
+
+// naoModificado responde 304 quando If-None-Match já traz a versão atual,
+// poupando o corpo da resposta. A comparação é fraca, como pede o RFC 9110.
+func naoModificado(w http.ResponseWriter, r *http.Request, versao int64) bool {
+	cabecalho := r.Header.Get("If-None-Match")
+	if cabecalho == "" {
+		return false
+	}
+
+	atual := etag(versao)
+	for _, valor := range strings.Split(cabecalho, ",") {
+		valor = strings.TrimPrefix(strings.TrimSpace(valor), "W/")
+		if valor == atual || valor == "*" {
+			escreverETag(w, versao)
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"soat-fiap/internal/core/domain"
+)
+
+func TestVersaoIfMatch(t *testing.T) {
+	casos := []struct {
+		descricao string
+		cabecalho string
+		esperada  int64
+		erro      error
+	}{
+		{"sem cabeçalho", "", 0, nil},
+		{"qualquer versão", "*", 0, nil},
+		{"um ETag", `"3"`, 3, nil},
+		{"lista com a versão atual", `"3", "4"`, 4, nil},
+		{"lista sem espaços", `"4","5"`, 4, nil},
+		{"lista sem a versão atual", `"2", "3"`, 0, domain.ErrVersaoDesatualizada},
+		{"ETag fraco", `W/"4"`, 0, domain.ErrVersaoDesatualizada},
+		{"ETags fracos ignorados na lista", `W/"4", "4"`, 4, nil},
+		{"valor que não é ETag", "4", 0, domain.ErrVersaoDesatualizada},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.descricao, func(t *testing.T) {
+			r := httptest.NewRequest("PUT", "/", nil)
+			if caso.cabecalho != "" {
+				r.Header.Set("If-Match", caso.cabecalho)
+			}
+
+			versao, err := versaoIfMatch(r, func() (int64, error) { return 4, nil })
+			if !errors.Is(err, caso.erro) || versao != caso.esperada {
+				t.Errorf("esperado %d, %v; obtido %d, %v", caso.esperada, caso.erro, versao, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpcoesCORS define quais origens web podem chamar a API pelo navegador.
+type OpcoesCORS struct {
+	// Origens aceitas: "*", esquema://host[:porta] ou esquema://*.dominio
+	// para qualquer subdomínio. Vazio desliga o CORS.
+	Origens     []string
+	Metodos     []string
+	Cabecalhos  []string
+	Expostos    []string
+	Credenciais bool
+	// CachePreflight é devolvido em Access-Control-Max-Age.
+	CachePreflight time.Duration
+}
+
+// CORS responde aos preflights e acrescenta os cabeçalhos Access-Control-*
+// nas respostas para origens aceitas. Envolve o router inteiro, como o
+// RequestID, porque o mux responderia 405 aos OPTIONS antes dos middlewares
+// das rotas. Requisições de origens não aceitas seguem sem os cabeçalhos, e o
+// navegador as bloqueia; preflights delas recebem 403.
+func CORS(o OpcoesCORS) func(http.Handler) http.Handler {
+	if len(o.Origens) == 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	metodos := strings.Join(o.Metodos, ", ")
+	cabecalhos := strings.Join(o.Cabecalhos, ", ")
+	expostos := strings.Join(o.Expostos, ", ")
+	cache := strconv.Itoa(int(o.CachePreflight.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Add("Vary", "Origin")
+
+			origem := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if origem == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !origemAceita(o.Origens, origem) {
+				if preflight {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if o.Credenciais || !contem(o.Origens, "*") {
+				h.Set("Access-Control-Allow-Origin", origem)
+			} else {
+				h.Set("Access-Control-Allow-Origin", "*")
+			}
+			if o.Credenciais {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if preflight {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+				h.Set("Access-Control-Allow-Methods", metodos)
+				if cabecalhos != "" {
+					h.Set("Access-Control-Allow-Headers", cabecalhos)
+				}
+				if o.CachePreflight > 0 {
+					h.Set("Access-Control-Max-Age", cache)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if expostos != "" {
+				h.Set("Access-Control-Expose-Headers", expostos)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func origemAceita(origens []string, origem string) bool {
+	for _, aceita := range origens {
+		if aceita == "*" || strings.EqualFold(strings.TrimSuffix(aceita, "/"), origem) {
+			return true
+		}
+		// esquema://*.dominio aceita qualquer subdomínio, mas não o domínio.
+		esquema, dominio, ok := strings.Cut(aceita, "://*.")
+		if ok && len(origem) > len(esquema+"://"+dominio) &&
+			strings.HasPrefix(strings.ToLower(origem), strings.ToLower(esquema)+"://") &&
+			strings.HasSuffix(strings.ToLower(origem), "."+strings.ToLower(dominio)) {
+			return true
+		}
+	}
+	return false
+}
+
+func contem(lista []string, valor string) bool {
+	for _, item := range lista {
+		if item == valor {
+			return true
+		}
+	}
+	return false
+}
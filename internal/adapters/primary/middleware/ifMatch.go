@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"soat-fiap/internal/adapters/primary/handlers"
+)
+
+// ExigirIfMatch rejeita com 428 as alterações (PUT e PATCH) enviadas sem
+// If-Match, obrigando o cliente a informar a versão que leu. Sem este
+// middleware o cabeçalho é opcional e a versão conferida é a lida pelo
+// serviço.
+func ExigirIfMatch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method == http.MethodPut || r.Method == http.MethodPatch) && r.Header.Get("If-Match") == "" {
+			handlers.ResponderIfMatchObrigatorio(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"soat-fiap/pkg/correlacao"
+	"soat-fiap/pkg/logger"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Log registra uma linha por requisição com método, rota, status e latência,
+// e coloca no contexto um logger com o request_id para os serviços e
+// repositórios. O request_id vem do contexto (ver RequestID). Quando há um
+// trace ativo (ver Rastreamento), trace_id e span_id também entram no logger
+// e o request_id vira atributo do span. O caminho concreto fica de fora: ele
+// pode trazer dados pessoais, como o CPF em /clientes/cpf/{cpf}.
+func Log(base *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inicio := time.Now()
+
+			requestID := correlacao.DoContexto(r.Context())
+			log := base.With("request_id", requestID)
+			if span := trace.SpanFromContext(r.Context()); span.SpanContext().IsValid() {
+				span.SetAttributes(attribute.String("http.request_id", requestID))
+				log = log.With("trace_id", span.SpanContext().TraceID().String(), "span_id", span.SpanContext().SpanID().String())
+			}
+			r = r.WithContext(logger.NoContexto(r.Context(), log))
+
+			rw := &respostaComStatus{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			nivel := slog.LevelInfo
+			switch {
+			case rw.status >= http.StatusInternalServerError:
+				nivel = slog.LevelError
+			case rw.status >= http.StatusBadRequest:
+				nivel = slog.LevelWarn
+			}
+
+			log.LogAttrs(r.Context(), nivel, "requisição atendida",
+				slog.String("metodo", r.Method),
+				slog.String("rota", rotaDaRequisicao(r)),
+				slog.Int("status", rw.status),
+				slog.Float64("latencia_ms", float64(time.Since(inicio).Microseconds())/1000),
+			)
+		})
+	}
+}
+
+// rotaDaRequisicao devolve o template da rota do mux (ex.: /api/v1/pedidos/{id}).
+func rotaDaRequisicao(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "desconhecida"
+}
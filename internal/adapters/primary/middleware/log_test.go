@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"soat-fiap/internal/adapters/primary/handlers"
+	"soat-fiap/internal/core/domain"
+
+	"github.com/gorilla/mux"
+)
+
+// O CPF da rota /clientes/cpf/{cpf} não pode aparecer no log de acesso nem
+// no corpo do erro, que também costuma ir para logs.
+func TestLogNaoRegistraCPFDoCaminho(t *testing.T) {
+	const cpf = "52998224725"
+
+	var saida bytes.Buffer
+	router := mux.NewRouter()
+	router.Use(Log(slog.New(slog.NewJSONHandler(&saida, nil))))
+	router.HandleFunc("/api/v1/clientes/cpf/{cpf}", func(w http.ResponseWriter, r *http.Request) {
+		handlers.ResponderErro(w, r, domain.ErrClienteNaoEncontrado)
+	})
+
+	resposta := httptest.NewRecorder()
+	router.ServeHTTP(resposta, httptest.NewRequest(http.MethodGet, "/api/v1/clientes/cpf/"+cpf, nil))
+
+	if resposta.Code != http.StatusNotFound {
+		t.Fatalf("esperado 404, obtido %d", resposta.Code)
+	}
+	if !strings.Contains(saida.String(), "/api/v1/clientes/cpf/{cpf}") {
+		t.Errorf("o log deve trazer o template da rota: %s", saida.String())
+	}
+	if strings.Contains(saida.String(), cpf) {
+		t.Errorf("o log contém o CPF: %s", saida.String())
+	}
+	corpo, _ := io.ReadAll(resposta.Body)
+	if strings.Contains(string(corpo), cpf) {
+		t.Errorf("o corpo do erro contém o CPF: %s", corpo)
+	}
+	if !strings.Contains(string(corpo), "*********25") {
+		t.Errorf("instance deve trazer o CPF mascarado: %s", corpo)
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metricas conta as requisições e mede a latência por método, rota e status.
+// A rota é o template do mux (ex.: /api/v1/pedidos/{id}), para não criar uma
+// série por ID. Deve ser registrado com Router.Use, que só roda para rotas
+// encontradas.
+func Metricas(registro prometheus.Registerer) mux.MiddlewareFunc {
+	requisicoes := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "soat_fiap",
+		Name:      "http_requisicoes_total",
+		Help:      "Requisições HTTP atendidas.",
+	}, []string{"metodo", "rota", "status"})
+	duracao := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "soat_fiap",
+		Name:      "http_requisicao_duracao_segundos",
+		Help:      "Latência das requisições HTTP.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"metodo", "rota", "status"})
+	registro.MustRegister(requisicoes, duracao)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inicio := time.Now()
+			rw := &respostaComStatus{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rw, r)
+
+			rota := rotaDaRequisicao(r)
+			status := strconv.Itoa(rw.status)
+			requisicoes.WithLabelValues(r.Method, rota, status).Inc()
+			duracao.WithLabelValues(r.Method, rota, status).Observe(time.Since(inicio).Seconds())
+		})
+	}
+}
+
+// respostaComStatus guarda o status escrito pelo handler.
+type respostaComStatus struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *respostaComStatus) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *respostaComStatus) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
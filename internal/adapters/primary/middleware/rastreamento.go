@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+)
+
+// Rastreamento abre um span de servidor por requisição, nomeado pelo template
+// da rota do mux, continuando o trace recebido em traceparent. Probes de
+// saúde e a coleta de métricas ficam de fora para não poluir os traces.
+func Rastreamento(servico string) mux.MiddlewareFunc {
+	return otelmux.Middleware(servico, otelmux.WithFilter(func(r *http.Request) bool {
+		return !strings.HasPrefix(r.URL.Path, "/api/v1/health") &&
+			r.URL.Path != "/metrics"
+	}))
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"net/http"
+
+	"soat-fiap/pkg/correlacao"
+)
+
+// RequestID aceita o X-Request-ID enviado pelo cliente (ou gera um), guarda
+// no contexto e devolve no cabeçalho da resposta. Envolve o router inteiro,
+// e não só as rotas, para que também as respostas 404/405 do mux tragam o ID.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := correlacao.Normalizar(r.Header.Get(correlacao.Cabecalho))
+		w.Header().Set(correlacao.Cabecalho, id)
+		next.ServeHTTP(w, r.WithContext(correlacao.NoContexto(r.Context(), id)))
+	})
+}
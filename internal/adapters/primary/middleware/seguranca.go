@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpcoesSeguranca configura os cabeçalhos de segurança das respostas.
+type OpcoesSeguranca struct {
+	// HSTS é o max-age do Strict-Transport-Security, enviado só em respostas
+	// HTTPS (TLS direto ou X-Forwarded-Proto: https); zero desliga.
+	HSTS            time.Duration
+	HSTSSubdominios bool
+	// CSP vale para as respostas da API; CSPDocumentacao para as páginas HTML
+	// servidas sob PrefixoDocumentacao (Swagger UI).
+	CSP                 string
+	CSPDocumentacao     string
+	PrefixoDocumentacao string
+}
+
+// CabecalhosSeguranca acrescenta X-Content-Type-Options, X-Frame-Options,
+// Referrer-Policy, Content-Security-Policy e, em HTTPS, HSTS a todas as
+// respostas, inclusive 404/405 do mux.
+func CabecalhosSeguranca(o OpcoesSeguranca) func(http.Handler) http.Handler {
+	hsts := ""
+	if o.HSTS > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(o.HSTS.Seconds()))
+		if o.HSTSSubdominios {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("X-Frame-Options", "DENY")
+			h.Set("Referrer-Policy", "no-referrer")
+
+			csp := o.CSP
+			if o.PrefixoDocumentacao != "" && strings.HasPrefix(r.URL.Path, o.PrefixoDocumentacao) {
+				csp = o.CSPDocumentacao
+			}
+			if csp != "" {
+				h.Set("Content-Security-Policy", csp)
+			}
+			if hsts != "" && (r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https") {
+				h.Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"soat-fiap/internal/adapters/primary/handlers"
+	"soat-fiap/internal/core/domain"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gorilla/mux"
+)
+
+func init() {
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+}
+
+// ValidacaoOpenAPI confere parâmetros e corpo de cada requisição contra a
+// especificação OpenAPI 3 da API (campos obrigatórios, tipos, enums como
+// StatusPedido, formatos e limites) antes de ela chegar ao
+// handler. As violações voltam todas juntas como 422, no mesmo formato dos
+// erros de validação do domínio. No modo estrito, campos que não constam da
+// especificação também são rejeitados. Rotas fora da especificação passam
+// sem validação.
+func ValidacaoOpenAPI(spec []byte, estrito bool) (mux.MiddlewareFunc, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("especificação OpenAPI inválida: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("especificação OpenAPI inválida: %w", err)
+	}
+	if estrito {
+		proibirCamposDesconhecidos(doc)
+	}
+
+	basePath := ""
+	if len(doc.Servers) > 0 {
+		basePath = doc.Servers[0].URL
+	}
+	opcoes := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	// Uploads não passam pelo validador, que leria o arquivo inteiro para a
+	// memória antes de o handler aplicar o limite de tamanho.
+	opcoesUpload := *opcoes
+	opcoesUpload.ExcludeRequestBody = true
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			caminho := strings.TrimPrefix(rotaDaRequisicao(r), basePath)
+			item := doc.Paths.Find(caminho)
+			if item == nil || item.GetOperation(r.Method) == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			operacao := item.GetOperation(r.Method)
+
+			if tipo := tipoCorpoJSON(operacao); tipo != "" && operacao.RequestBody.Value.Content.Get(r.Header.Get("Content-Type")) == nil {
+				// Os handlers sempre decodificaram JSON sem olhar o
+				// Content-Type, e clientes como o fetch do navegador mandam
+				// text/plain por padrão; o corpo é validado como JSON.
+				r.Header.Set("Content-Type", tipo)
+			}
+
+			entrada := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: mux.Vars(r),
+				Route: &routers.Route{
+					Spec:      doc,
+					Path:      caminho,
+					PathItem:  item,
+					Method:    r.Method,
+					Operation: operacao,
+				},
+				Options: opcoes,
+			}
+			if recebeArquivo(operacao) {
+				entrada.Options = &opcoesUpload
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), entrada); err != nil {
+				responderViolacoes(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// tipoCorpoJSON devolve o tipo de mídia a assumir quando a operação só
+// recebe corpos JSON (application/json ou variantes +json, como o
+// application/merge-patch+json do PATCH), ou vazio se aceita outros formatos.
+func tipoCorpoJSON(operacao *openapi3.Operation) string {
+	if operacao.RequestBody == nil || operacao.RequestBody.Value == nil {
+		return ""
+	}
+	conteudo := operacao.RequestBody.Value.Content
+	if conteudo.Get("application/json") == nil {
+		return ""
+	}
+	for tipo := range conteudo {
+		if tipo != "application/json" && !strings.HasSuffix(tipo, "+json") {
+			return ""
+		}
+	}
+	return "application/json"
+}
+
+func recebeArquivo(operacao *openapi3.Operation) bool {
+	return operacao.RequestBody != nil && operacao.RequestBody.Value != nil &&
+		operacao.RequestBody.Value.Content.Get("multipart/form-data") != nil
+}
+
+// responderViolacoes separa as violações de esquema, que viram erros de
+// campo (422), dos problemas que impedem ler a requisição, como JSON
+// malformado ou Content-Type não suportado (400).
+func responderViolacoes(w http.ResponseWriter, r *http.Request, err error) {
+	var erros domain.ErrosValidacao
+	var ilegivel error
+	coletarViolacoes(err, nil, &erros, &ilegivel)
+
+	if ilegivel != nil {
+		handlers.ResponderRequisicaoInvalida(w, r, ilegivel)
+		return
+	}
+	if err := erros.Erro(); err != nil {
+		handlers.ResponderErro(w, r, err)
+		return
+	}
+	handlers.ResponderRequisicaoInvalida(w, r, err)
+}
+
+// coletarViolacoes percorre a árvore de erros do validador. Os tipos são
+// testados diretamente, e não com errors.As, porque um SchemaError de allOf
+// embrulha os erros internos e o caminho do campo fica só no externo.
+func coletarViolacoes(err error, caminho []string, erros *domain.ErrosValidacao, ilegivel *error) {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, interno := range e {
+			coletarViolacoes(interno, caminho, erros, ilegivel)
+		}
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			caminho = []string{e.Parameter.Name}
+		}
+		switch {
+		case e.Err == nil:
+			*ilegivel = errors.New(e.Reason)
+		case errors.Is(e.Err, openapi3filter.ErrInvalidRequired) && e.Parameter != nil:
+			erros.Adicionar(e.Parameter.Name, "OBRIGATORIO", "parâmetro obrigatório")
+		case errors.Is(e.Err, openapi3filter.ErrInvalidRequired):
+			*ilegivel = errors.New("corpo obrigatório")
+		default:
+			coletarViolacoes(e.Err, caminho, erros, ilegivel)
+		}
+	case *openapi3.SchemaError:
+		caminho = append(append([]string(nil), caminho...), e.JSONPointer()...)
+		var internos openapi3.MultiError
+		if e.Origin != nil && errors.As(e.Origin, &internos) {
+			coletarViolacoes(internos, caminho, erros, ilegivel)
+			return
+		}
+		if e.SchemaField == "properties" {
+			// Campo desconhecido no modo estrito: o validador não inclui o
+			// nome do campo no caminho, só na mensagem.
+			if m := campoDesconhecido.FindStringSubmatch(e.Reason); m != nil {
+				erros.Adicionar(nomeCampo(append(caminho, m[1])), "CAMPO_DESCONHECIDO", "campo não permitido")
+				return
+			}
+		}
+		codigo, mensagem := descreverViolacao(e)
+		erros.Adicionar(nomeCampo(caminho), codigo, mensagem)
+	case *openapi3filter.ParseError:
+		if len(caminho) > 0 {
+			erros.Adicionar(nomeCampo(caminho), "TIPO_INVALIDO", "valor inválido: "+e.Reason)
+			return
+		}
+		*ilegivel = e
+	default:
+		*ilegivel = err
+	}
+}
+
+var campoDesconhecido = regexp.MustCompile(`^property "(.+)" is unsupported$`)
+
+// nomeCampo converte o caminho do erro para a notação usada nos erros do
+// domínio: ["itens", "2", "quantidade"] vira itens[2].quantidade.
+func nomeCampo(caminho []string) string {
+	var b strings.Builder
+	for _, parte := range caminho {
+		if _, err := strconv.Atoi(parte); err == nil {
+			b.WriteString("[" + parte + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(parte)
+	}
+	return b.String()
+}
+
+func descreverViolacao(e *openapi3.SchemaError) (codigo, mensagem string) {
+	s := e.Schema
+	switch e.SchemaField {
+	case "required":
+		return "OBRIGATORIO", "campo obrigatório"
+	case "type":
+		if s != nil && s.Type != nil {
+			return "TIPO_INVALIDO", "valor deve ser do tipo " + strings.Join(s.Type.Slice(), " ou ")
+		}
+		return "TIPO_INVALIDO", "tipo inválido"
+	case "nullable":
+		return "TIPO_INVALIDO", "valor não pode ser nulo"
+	case "enum":
+		valores := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			valores[i] = fmt.Sprint(v)
+		}
+		return "INVALIDO", "valor deve ser um de: " + strings.Join(valores, ", ")
+	case "format":
+		return "FORMATO_INVALIDO", "formato inválido, esperado " + s.Format
+	case "minLength":
+		if s.MinLength == 1 {
+			return "OBRIGATORIO", "não pode ser vazio"
+		}
+		return "FORA_DO_INTERVALO", fmt.Sprintf("deve ter pelo menos %d caracteres", s.MinLength)
+	case "maxLength":
+		return "FORA_DO_INTERVALO", fmt.Sprintf("deve ter no máximo %d caracteres", *s.MaxLength)
+	case "minimum":
+		return "FORA_DO_INTERVALO", "valor deve ser maior ou igual a " + strconv.FormatFloat(*s.Min, 'f', -1, 64)
+	case "maximum":
+		return "FORA_DO_INTERVALO", "valor deve ser menor ou igual a " + strconv.FormatFloat(*s.Max, 'f', -1, 64)
+	default:
+		return "INVALIDO", e.Reason
+	}
+}
+
+// proibirCamposDesconhecidos marca como fechados (additionalProperties:
+// false) todos os objetos aceitos nos corpos de requisição.
+func proibirCamposDesconhecidos(doc *openapi3.T) {
+	visitados := make(map[*openapi3.Schema]bool)
+	var fechar func(ref *openapi3.SchemaRef)
+	fechar = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil || visitados[ref.Value] {
+			return
+		}
+		s := ref.Value
+		visitados[s] = true
+
+		if s.Type.Is(openapi3.TypeObject) || len(s.Properties) > 0 {
+			nao := false
+			s.AdditionalProperties = openapi3.AdditionalProperties{Has: &nao}
+		}
+		for _, propriedade := range s.Properties {
+			fechar(propriedade)
+		}
+		fechar(s.Items)
+		for _, grupo := range []openapi3.SchemaRefs{s.AllOf, s.AnyOf, s.OneOf} {
+			for _, sub := range grupo {
+				fechar(sub)
+			}
+		}
+	}
+
+	for _, item := range doc.Paths.Map() {
+		for _, operacao := range item.Operations() {
+			if operacao.RequestBody == nil || operacao.RequestBody.Value == nil {
+				continue
+			}
+			for _, midia := range operacao.RequestBody.Value.Content {
+				fechar(midia.Schema)
+			}
+		}
+	}
+}
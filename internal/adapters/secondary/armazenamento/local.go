@@ -0,0 +1,103 @@
+package armazenamento
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local guarda os arquivos em um diretório do sistema de arquivos. Com
+// várias instâncias da API, o diretório precisa ser compartilhado (ex.: um
+// volume de rede); as URLs apontam para urlBase, servida por Handler ou por
+// um servidor de arquivos na frente da API.
+type Local struct {
+	diretorio string
+	urlBase   string
+}
+
+func NovoArmazenamentoLocal(diretorio, urlBase string) (*Local, error) {
+	if err := os.MkdirAll(diretorio, 0o755); err != nil {
+		return nil, fmt.Errorf("diretório de arquivos inacessível: %w", err)
+	}
+	return &Local{
+		diretorio: diretorio,
+		urlBase:   strings.TrimSuffix(urlBase, "/"),
+	}, nil
+}
+
+// Salvar escreve em um arquivo temporário e o renomeia ao final, para que
+// quem lê a URL nunca receba um arquivo pela metade.
+func (a *Local) Salvar(ctx context.Context, chave, tipo string, conteudo io.Reader) error {
+	caminho, err := a.caminho(chave)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(caminho), 0o755); err != nil {
+		return err
+	}
+
+	temporario, err := os.CreateTemp(filepath.Dir(caminho), ".envio-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporario.Name())
+
+	if _, err := io.Copy(temporario, conteudo); err != nil {
+		temporario.Close()
+		return err
+	}
+	if err := temporario.Chmod(0o644); err != nil {
+		temporario.Close()
+		return err
+	}
+	if err := temporario.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporario.Name(), caminho)
+}
+
+func (a *Local) Remover(ctx context.Context, chave string) error {
+	caminho, err := a.caminho(chave)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(caminho); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (a *Local) URL(chave string) string {
+	return a.urlBase + "/" + chave
+}
+
+// Handler serve os arquivos do diretório, sem listar o conteúdo das pastas.
+// Como cada envio gera uma chave nova, as respostas podem ficar em cache
+// indefinidamente.
+func (a *Local) Handler() http.Handler {
+	arquivos := http.FileServer(http.Dir(a.diretorio))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") || strings.Contains(r.URL.Path, "/.") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		arquivos.ServeHTTP(w, r)
+	})
+}
+
+// caminho converte a chave em um caminho dentro do diretório, recusando
+// chaves que escapariam dele.
+func (a *Local) caminho(chave string) (string, error) {
+	relativo := filepath.FromSlash(chave)
+	if !filepath.IsLocal(relativo) {
+		return "", fmt.Errorf("chave de arquivo inválida: %q", chave)
+	}
+	return filepath.Join(a.diretorio, relativo), nil
+}
@@ -0,0 +1,27 @@
+// Package metricas expõe métricas da aplicação no formato do Prometheus.
+package metricas
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "soat_fiap"
+
+// NovoRegistro cria o registro com as métricas do runtime Go e do processo.
+func NovoRegistro() *prometheus.Registry {
+	registro := prometheus.NewRegistry()
+	registro.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registro
+}
+
+// RegistrarPool publica as estatísticas do pool de conexões (sql.DBStats)
+// como go_sql_* com o rótulo db_name igual ao driver.
+func RegistrarPool(registro prometheus.Registerer, db *sql.DB, driver string) {
+	registro.MustRegister(collectors.NewDBStatsCollector(db, driver))
+}
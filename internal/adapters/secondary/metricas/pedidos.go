@@ -0,0 +1,108 @@
+package metricas
+
+import (
+	"context"
+	"time"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// prazoContagem limita a consulta feita a cada coleta do Prometheus.
+const prazoContagem = 5 * time.Second
+
+// MetricasPedidos publica no Prometheus os eventos recebidos do PedidoService.
+// Os valores são da instância desde que ela subiu; some as réplicas nas
+// consultas para obter o total.
+type MetricasPedidos struct {
+	criados       prometheus.Counter
+	valorCheckout prometheus.Counter
+	tempoNoStatus *prometheus.HistogramVec
+}
+
+func NovoMetricasPedidos(registro prometheus.Registerer) *MetricasPedidos {
+	m := &MetricasPedidos{
+		criados: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pedidos_criados_total",
+			Help:      "Pedidos criados no checkout.",
+		}),
+		valorCheckout: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "checkout_valor_reais_total",
+			Help:      "Soma do valor total dos pedidos criados, em reais.",
+		}),
+		tempoNoStatus: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "pedido_tempo_no_status_segundos",
+			Help:      "Tempo que o pedido ficou em um status antes de mudar para o próximo.",
+			// De 15 segundos a cerca de 2 horas.
+			Buckets: prometheus.ExponentialBuckets(15, 2, 10),
+		}, []string{"status"}),
+	}
+
+	registro.MustRegister(m.criados, m.valorCheckout, m.tempoNoStatus)
+	return m
+}
+
+func (m *MetricasPedidos) PedidoCriado(pedido *domain.Pedido) {
+	m.criados.Inc()
+	m.valorCheckout.Add(pedido.ValorTotal)
+}
+
+func (m *MetricasPedidos) StatusAlterado(pedido *domain.Pedido, anterior domain.StatusPedido, tempoNoStatus time.Duration) {
+	m.tempoNoStatus.WithLabelValues(string(anterior)).Observe(tempoNoStatus.Seconds())
+}
+
+// ContadorPedidos é a parte do PedidoRepository usada pela métrica de pedidos
+// por status.
+type ContadorPedidos interface {
+	ContarPorStatus(ctx context.Context) (map[domain.StatusPedido]int, error)
+}
+
+// RegistrarPedidosPorStatus publica pedidos_por_status, contado no banco a
+// cada coleta. Como vem dos dados gravados, inclui os pedidos anteriores à
+// subida da instância e é igual em todas as réplicas.
+func RegistrarPedidosPorStatus(registro prometheus.Registerer, contador ContadorPedidos) {
+	registro.MustRegister(&coletorPedidosPorStatus{
+		contador: contador,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "pedidos_por_status"),
+			"Pedidos gravados em cada status.",
+			[]string{"status"}, nil,
+		),
+	})
+}
+
+type coletorPedidosPorStatus struct {
+	contador ContadorPedidos
+	desc     *prometheus.Desc
+}
+
+func (c *coletorPedidosPorStatus) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect omite a métrica quando a contagem falha, para que um banco
+// indisponível não derrube a coleta das demais métricas.
+func (c *coletorPedidosPorStatus) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), prazoContagem)
+	defer cancel()
+
+	contagem, err := c.contador.ContarPorStatus(ctx)
+	if err != nil {
+		logger.DoContexto(ctx).Warn("erro ao contar pedidos por status", "erro", err)
+		return
+	}
+
+	for _, status := range []domain.StatusPedido{domain.StatusRecebido, domain.StatusEmPreparacao, domain.StatusPronto, domain.StatusFinalizado} {
+		if _, ok := contagem[status]; !ok {
+			contagem[status] = 0
+		}
+	}
+	for status, total := range contagem {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(total), string(status))
+	}
+}
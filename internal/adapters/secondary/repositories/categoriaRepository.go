@@ -0,0 +1,285 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"soat-fiap/internal/core/domain"
+	"time"
+)
+
+type CategoriaRepository struct {
+	db *sql.DB
+}
+
+func NovoCategoriaRepository(db *sql.DB) *CategoriaRepository {
+	return &CategoriaRepository{
+		db: db,
+	}
+}
+
+func (r *CategoriaRepository) Criar(ctx context.Context, categoria *domain.CategoriaProduto) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO categorias (codigo, nome, icone, ordem, ativa, created_at, updated_at, versao)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		categoria.Codigo,
+		categoria.Nome,
+		categoria.Icone,
+		categoria.Ordem,
+		categoria.Ativa,
+		categoria.CreatedAt.Format(time.RFC3339),
+		categoria.UpdatedAt.Format(time.RFC3339),
+		categoria.Versao,
+	)
+	if err = traduzirErro(err); errors.Is(err, domain.ErrConflito) {
+		return domain.ErrCategoriaDuplicada
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := gravarTraducoes(ctx, tx, categoria); err != nil {
+		return err
+	}
+
+	return traduzirErro(tx.Commit())
+}
+
+func (r *CategoriaRepository) BuscarPorCodigo(ctx context.Context, codigo domain.Categoria) (*domain.CategoriaProduto, error) {
+	var categoria domain.CategoriaProduto
+	var createdAtStr, updatedAtStr string
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT codigo, nome, icone, ordem, ativa, created_at, updated_at, versao
+		FROM categorias
+		WHERE codigo = ?
+	`, codigo).Scan(
+		&categoria.Codigo,
+		&categoria.Nome,
+		&categoria.Icone,
+		&categoria.Ordem,
+		&categoria.Ativa,
+		&createdAtStr,
+		&updatedAtStr,
+		&categoria.Versao,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, traduzirErro(err)
+	}
+
+	categoria.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
+	categoria.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAtStr)
+
+	traducoes, err := r.lerTraducoes(ctx, "WHERE categoria_codigo = ?", codigo)
+	if err != nil {
+		return nil, err
+	}
+	categoria.Traducoes = traducoesDe(traducoes, codigo)
+
+	return &categoria, nil
+}
+
+func (r *CategoriaRepository) Listar(ctx context.Context, filtro domain.FiltroCategorias) ([]*domain.CategoriaProduto, error) {
+	var c consulta
+	if filtro.Ativa != nil {
+		c.onde("ativa = ?", *filtro.Ativa)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT codigo, nome, icone, ordem, ativa, created_at, updated_at, versao
+		FROM categorias
+		`+c.clausulaWhere()+`
+		ORDER BY ordem, codigo
+	`, c.args...)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	var categorias []*domain.CategoriaProduto
+
+	for rows.Next() {
+		var categoria domain.CategoriaProduto
+		var createdAtStr, updatedAtStr string
+
+		err := rows.Scan(
+			&categoria.Codigo,
+			&categoria.Nome,
+			&categoria.Icone,
+			&categoria.Ordem,
+			&categoria.Ativa,
+			&createdAtStr,
+			&updatedAtStr,
+			&categoria.Versao,
+		)
+		if err != nil {
+			return nil, traduzirErro(err)
+		}
+
+		categoria.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
+		categoria.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAtStr)
+
+		categorias = append(categorias, &categoria)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, traduzirErro(err)
+	}
+
+	traducoes, err := r.lerTraducoes(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, categoria := range categorias {
+		categoria.Traducoes = traducoesDe(traducoes, categoria.Codigo)
+	}
+
+	return categorias, nil
+}
+
+// Atualizar regrava as traduções na mesma transação que os demais campos.
+func (r *CategoriaRepository) Atualizar(ctx context.Context, categoria *domain.CategoriaProduto) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE categorias
+		SET nome = ?, icone = ?, ordem = ?, ativa = ?, updated_at = ?, versao = versao + 1
+		WHERE codigo = ? AND versao = ?
+	`,
+		categoria.Nome,
+		categoria.Icone,
+		categoria.Ordem,
+		categoria.Ativa,
+		categoria.UpdatedAt.Format(time.RFC3339),
+		categoria.Codigo,
+		categoria.Versao,
+	)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		var existe int
+		err := tx.QueryRowContext(ctx, "SELECT 1 FROM categorias WHERE codigo = ?", categoria.Codigo).Scan(&existe)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return domain.ErrCategoriaNaoEncontrada
+		case err != nil:
+			return traduzirErro(err)
+		default:
+			return domain.ErrVersaoDesatualizada
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM categoria_traducoes WHERE categoria_codigo = ?", categoria.Codigo); err != nil {
+		return traduzirErro(err)
+	}
+	if err := gravarTraducoes(ctx, tx, categoria); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return traduzirErro(err)
+	}
+
+	categoria.Versao++
+	return nil
+}
+
+// Deletar apaga a categoria e, em cascata, as traduções. Categorias com
+// produtos são protegidas pela chave estrangeira de produtos.categoria.
+func (r *CategoriaRepository) Deletar(ctx context.Context, codigo domain.Categoria) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM categorias WHERE codigo = ?", codigo)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrCategoriaNaoEncontrada
+	}
+
+	return nil
+}
+
+func (r *CategoriaRepository) EmUso(ctx context.Context, codigo domain.Categoria) (bool, error) {
+	var existe int
+	err := r.db.QueryRowContext(ctx, "SELECT 1 FROM produtos WHERE categoria = ? LIMIT 1", codigo).Scan(&existe)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, traduzirErro(err)
+	}
+	return true, nil
+}
+
+func gravarTraducoes(ctx context.Context, db executor, categoria *domain.CategoriaProduto) error {
+	for idioma, nome := range categoria.Traducoes {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO categoria_traducoes (categoria_codigo, idioma, nome)
+			VALUES (?, ?, ?)
+		`, categoria.Codigo, idioma, nome)
+		if err != nil {
+			return traduzirErro(err)
+		}
+	}
+	return nil
+}
+
+// lerTraducoes agrupa as traduções por categoria; where restringe as
+// categorias lidas.
+func (r *CategoriaRepository) lerTraducoes(ctx context.Context, where string, args ...any) (map[domain.Categoria]map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT categoria_codigo, idioma, nome FROM categoria_traducoes "+where, args...)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	traducoes := make(map[domain.Categoria]map[string]string)
+	for rows.Next() {
+		var codigo domain.Categoria
+		var idioma, nome string
+		if err := rows.Scan(&codigo, &idioma, &nome); err != nil {
+			return nil, traduzirErro(err)
+		}
+		if traducoes[codigo] == nil {
+			traducoes[codigo] = make(map[string]string)
+		}
+		traducoes[codigo][idioma] = nome
+	}
+
+	return traducoes, traduzirErro(rows.Err())
+}
+
+// traducoesDe nunca devolve nil, para que a categoria sem traduções seja
+// serializada com um objeto vazio.
+func traducoesDe(traducoes map[domain.Categoria]map[string]string, codigo domain.Categoria) map[string]string {
+	if t := traducoes[codigo]; t != nil {
+		return t
+	}
+	return map[string]string{}
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"soat-fiap/internal/core/domain"
 	"time"
 )
@@ -19,11 +20,11 @@ func NovoClienteRepository(db *sql.DB) *ClienteRepository {
 
 func (r *ClienteRepository) Criar(ctx context.Context, cliente *domain.Cliente) error {
 	stmt, err := r.db.PrepareContext(ctx, `
-		INSERT INTO clientes (id, nome, cpf, email, telefone, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO clientes (id, nome, cpf, email, telefone, created_at, updated_at, versao)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 	defer stmt.Close()
 
@@ -35,19 +36,23 @@ func (r *ClienteRepository) Criar(ctx context.Context, cliente *domain.Cliente)
 		cliente.Telefone,
 		cliente.CreatedAt.Format(time.RFC3339),
 		cliente.UpdatedAt.Format(time.RFC3339),
+		cliente.Versao,
 	)
+	if err = traduzirErro(err); errors.Is(err, domain.ErrConflito) {
+		return domain.ErrCPFDuplicado
+	}
 
 	return err
 }
 
 func (r *ClienteRepository) BuscarPorID(ctx context.Context, id string) (*domain.Cliente, error) {
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, nome, cpf, email, telefone, created_at, updated_at
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
 		FROM clientes
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`)
 	if err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 	defer stmt.Close()
 
@@ -62,13 +67,15 @@ func (r *ClienteRepository) BuscarPorID(ctx context.Context, id string) (*domain
 		&cliente.Telefone,
 		&createdAtStr,
 		&updatedAtStr,
+		&cliente.Versao,
+		&cliente.DeletedAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 
 	cliente.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
@@ -79,12 +86,12 @@ func (r *ClienteRepository) BuscarPorID(ctx context.Context, id string) (*domain
 
 func (r *ClienteRepository) BuscarPorCPF(ctx context.Context, cpf string) (*domain.Cliente, error) {
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, nome, cpf, email, telefone, created_at, updated_at
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
 		FROM clientes
-		WHERE cpf = ?
+		WHERE cpf = ? AND deleted_at IS NULL
 	`)
 	if err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 	defer stmt.Close()
 
@@ -99,13 +106,15 @@ func (r *ClienteRepository) BuscarPorCPF(ctx context.Context, cpf string) (*doma
 		&cliente.Telefone,
 		&createdAtStr,
 		&updatedAtStr,
+		&cliente.Versao,
+		&cliente.DeletedAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 
 	cliente.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
@@ -114,14 +123,33 @@ func (r *ClienteRepository) BuscarPorCPF(ctx context.Context, cpf string) (*doma
 	return &cliente, nil
 }
 
-func (r *ClienteRepository) Listar(ctx context.Context) ([]*domain.Cliente, error) {
+var colunasOrdenacaoClientes = map[string]colunaOrdenacao{
+	domain.OrdenarClientesPorNome:    {nome: "nome", converter: cursorTexto},
+	domain.OrdenarClientesPorCriacao: {nome: "created_at", converter: cursorTempo},
+}
+
+func (r *ClienteRepository) Listar(ctx context.Context, filtro domain.FiltroClientes) (*domain.Pagina[*domain.Cliente], error) {
+	var c consulta
+	if filtro.Nome != "" {
+		c.onde("nome LIKE ?", "%"+filtro.Nome+"%")
+	}
+	if filtro.Removidos {
+		c.onde("deleted_at IS NOT NULL")
+	} else {
+		c.onde("deleted_at IS NULL")
+	}
+	ordenacao, err := c.paginar(filtro.Paginacao, colunasOrdenacaoClientes)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, nome, cpf, email, telefone, created_at, updated_at
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
 		FROM clientes
-		ORDER BY nome
-	`)
+		`+c.clausulaWhere()+`
+		`+ordenacao, c.args...)
 	if err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 	defer rows.Close()
 
@@ -139,9 +167,11 @@ func (r *ClienteRepository) Listar(ctx context.Context) ([]*domain.Cliente, erro
 			&cliente.Telefone,
 			&createdAtStr,
 			&updatedAtStr,
+			&cliente.Versao,
+			&cliente.DeletedAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, traduzirErro(err)
 		}
 
 		cliente.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
@@ -151,20 +181,22 @@ func (r *ClienteRepository) Listar(ctx context.Context) ([]*domain.Cliente, erro
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 
-	return clientes, nil
+	return domain.NovaPagina(clientes, filtro.Paginacao, func(c *domain.Cliente) (string, string) {
+		return c.ValorOrdenacao(filtro.Ordenacao), c.ID
+	}), nil
 }
 
 func (r *ClienteRepository) Atualizar(ctx context.Context, cliente *domain.Cliente) error {
 	stmt, err := r.db.PrepareContext(ctx, `
 		UPDATE clientes
-		SET nome = ?, cpf = ?, email = ?, telefone = ?, updated_at = ?
-		WHERE id = ?
+		SET nome = ?, cpf = ?, email = ?, telefone = ?, updated_at = ?, versao = versao + 1
+		WHERE id = ? AND versao = ? AND deleted_at IS NULL
 	`)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 	defer stmt.Close()
 
@@ -175,45 +207,59 @@ func (r *ClienteRepository) Atualizar(ctx context.Context, cliente *domain.Clien
 		cliente.Telefone,
 		cliente.UpdatedAt.Format(time.RFC3339),
 		cliente.ID,
+		cliente.Versao,
 	)
+	if err = traduzirErro(err); errors.Is(err, domain.ErrConflito) {
+		return domain.ErrCPFDuplicado
+	}
 	if err != nil {
 		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return versaoOuNaoEncontrado(ctx, r.db, "clientes", cliente.ID, domain.ErrClienteNaoEncontrado)
 	}
 
+	cliente.Versao++
 	return nil
 }
 
 func (r *ClienteRepository) Deletar(ctx context.Context, id string) error {
-	stmt, err := r.db.PrepareContext(ctx, `
-		DELETE FROM clientes
-		WHERE id = ?
-	`)
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
+	return r.marcarRemocao(ctx, `
+		UPDATE clientes
+		SET deleted_at = ?, versao = versao + 1
+		WHERE id = ? AND deleted_at IS NULL
+	`, time.Now().UTC().Format(time.RFC3339), id)
+}
+
+func (r *ClienteRepository) Restaurar(ctx context.Context, id string) error {
+	return r.marcarRemocao(ctx, `
+		UPDATE clientes
+		SET deleted_at = NULL, versao = versao + 1
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`, id)
+}
 
-	result, err := stmt.ExecContext(ctx, id)
+// marcarRemocao executa a remoção lógica ou a restauração; nenhuma linha
+// afetada significa que não há cliente no estado esperado.
+func (r *ClienteRepository) marcarRemocao(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return domain.ErrClienteNaoEncontrado
 	}
 
 	return nil
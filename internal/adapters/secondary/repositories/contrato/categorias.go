@@ -0,0 +1,132 @@
+package contrato
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/internal/core/ports"
+)
+
+// VerificarCategorias exercita um CategoriaRepository que tem só as
+// categorias padrão (LANCHE, ACOMPANHAMENTO, BEBIDA e SOBREMESA), depois que
+// VerificarProdutos cadastrou produtos nelas.
+func VerificarCategorias(ctx context.Context, repo ports.CategoriaRepository) error {
+	if categoria, err := repo.BuscarPorCodigo(ctx, "INEXISTENTE"); err != nil || categoria != nil {
+		return fmt.Errorf("BuscarPorCodigo de código inexistente deve retornar nil, nil; obtido %v, %v", categoria, err)
+	}
+	lanche, err := repo.BuscarPorCodigo(ctx, "LANCHE")
+	if err != nil || lanche == nil || !lanche.Ativa || lanche.Traducoes == nil {
+		return fmt.Errorf("a categoria padrão LANCHE deve existir, ativa: %+v, %v", lanche, err)
+	}
+
+	categorias := []*domain.CategoriaProduto{
+		{Codigo: "TESTE_B", Nome: "Teste B", Ordem: 10, Ativa: true, Traducoes: map[string]string{"en": "Test B", "es": "Prueba B"}, CreatedAt: instante(1), UpdatedAt: instante(1), Versao: 1},
+		{Codigo: "TESTE_A", Nome: "Teste A", Icone: "teste-a.svg", Ordem: 10, Ativa: true, Traducoes: map[string]string{}, CreatedAt: instante(2), UpdatedAt: instante(2), Versao: 1},
+		{Codigo: "TESTE_Ç", Nome: "Teste Ç", Ordem: 5, Ativa: false, Traducoes: map[string]string{}, CreatedAt: instante(3), UpdatedAt: instante(3), Versao: 1},
+	}
+	for _, categoria := range categorias {
+		if err := repo.Criar(ctx, categoria); err != nil {
+			return fmt.Errorf("Criar %s: %w", categoria.Codigo, err)
+		}
+	}
+
+	duplicada := *categorias[0]
+	if err := esperarErro(repo.Criar(ctx, &duplicada), domain.ErrCategoriaDuplicada, "Criar com código repetido"); err != nil {
+		return err
+	}
+
+	obtida, err := repo.BuscarPorCodigo(ctx, "TESTE_B")
+	if err != nil || obtida == nil {
+		return fmt.Errorf("BuscarPorCodigo: %v, %v", obtida, err)
+	}
+	if obtida.Nome != "Teste B" || obtida.Ordem != 10 || !obtida.Ativa || !maps.Equal(obtida.Traducoes, categorias[0].Traducoes) || !mesmoInstante(obtida.CreatedAt, instante(1)) || obtida.Versao != 1 {
+		return fmt.Errorf("BuscarPorCodigo retornou dados diferentes dos gravados: %+v", obtida)
+	}
+	obtida, err = repo.BuscarPorCodigo(ctx, "TESTE_Ç")
+	if err != nil || obtida == nil || obtida.Ativa || obtida.Traducoes == nil || len(obtida.Traducoes) != 0 {
+		return fmt.Errorf("BuscarPorCodigo de categoria inativa sem traduções: %+v, %v", obtida, err)
+	}
+
+	codigoCategoria := func(c *domain.CategoriaProduto) string { return string(c.Codigo) }
+	todas, err := repo.Listar(ctx, domain.FiltroCategorias{})
+	if err != nil {
+		return fmt.Errorf("Listar: %w", err)
+	}
+	if err := mesmaSequencia(ids(todas, codigoCategoria), "LANCHE", "ACOMPANHAMENTO", "BEBIDA", "SOBREMESA", "TESTE_Ç", "TESTE_A", "TESTE_B"); err != nil {
+		return fmt.Errorf("Listar por ordem e código: %w", err)
+	}
+	if !maps.Equal(todas[6].Traducoes, categorias[0].Traducoes) || todas[5].Icone != "teste-a.svg" {
+		return fmt.Errorf("Listar deve trazer ícone e traduções: %+v, %+v", todas[5], todas[6])
+	}
+	inativas, err := repo.Listar(ctx, domain.FiltroCategorias{Ativa: ptr(false)})
+	if err != nil {
+		return fmt.Errorf("Listar inativas: %w", err)
+	}
+	if err := mesmaSequencia(ids(inativas, codigoCategoria), "TESTE_Ç"); err != nil {
+		return fmt.Errorf("Listar inativas: %w", err)
+	}
+
+	atualizada := *categorias[0]
+	atualizada.Nome = "Teste B2"
+	atualizada.Ordem = 1
+	atualizada.Ativa = false
+	atualizada.Traducoes = map[string]string{"en": "Test B2", "pt-PT": "Teste B2"}
+	atualizada.UpdatedAt = instante(10)
+	if err := repo.Atualizar(ctx, &atualizada); err != nil {
+		return fmt.Errorf("Atualizar: %w", err)
+	}
+	obtida, err = repo.BuscarPorCodigo(ctx, "TESTE_B")
+	if err != nil || obtida == nil || obtida.Nome != "Teste B2" || obtida.Ordem != 1 || obtida.Ativa || !maps.Equal(obtida.Traducoes, atualizada.Traducoes) || !mesmoInstante(obtida.UpdatedAt, instante(10)) || !mesmoInstante(obtida.CreatedAt, instante(1)) {
+		return fmt.Errorf("Atualizar não persistiu as alterações: %+v, %v", obtida, err)
+	}
+	if atualizada.Versao != 2 || obtida.Versao != 2 {
+		return fmt.Errorf("Atualizar deve incrementar a versão para 2; obtido %d no argumento e %d gravada", atualizada.Versao, obtida.Versao)
+	}
+
+	desatualizada := *categorias[0]
+	desatualizada.Nome = "Teste B3"
+	if err := esperarErro(repo.Atualizar(ctx, &desatualizada), domain.ErrVersaoDesatualizada, "Atualizar com versão antiga"); err != nil {
+		return err
+	}
+	if obtida, err := repo.BuscarPorCodigo(ctx, "TESTE_B"); err != nil || obtida == nil || obtida.Nome != "Teste B2" || !maps.Equal(obtida.Traducoes, atualizada.Traducoes) {
+		return fmt.Errorf("Atualizar com versão antiga não deve alterar o registro: %+v, %v", obtida, err)
+	}
+
+	inexistente := *categorias[1]
+	inexistente.Codigo = "INEXISTENTE"
+	if err := esperarErro(repo.Atualizar(ctx, &inexistente), domain.ErrCategoriaNaoEncontrada, "Atualizar inexistente"); err != nil {
+		return err
+	}
+
+	if emUso, err := repo.EmUso(ctx, "LANCHE"); err != nil || !emUso {
+		return fmt.Errorf("EmUso de categoria com produtos deve ser true: %v, %v", emUso, err)
+	}
+	if emUso, err := repo.EmUso(ctx, "TESTE_A"); err != nil || emUso {
+		return fmt.Errorf("EmUso de categoria sem produtos deve ser false: %v, %v", emUso, err)
+	}
+
+	if err := repo.Deletar(ctx, "TESTE_B"); err != nil {
+		return fmt.Errorf("Deletar: %w", err)
+	}
+	if obtida, err := repo.BuscarPorCodigo(ctx, "TESTE_B"); err != nil || obtida != nil {
+		return fmt.Errorf("categoria deletada ainda é encontrada: %v, %v", obtida, err)
+	}
+	if err := esperarErro(repo.Deletar(ctx, "TESTE_B"), domain.ErrCategoriaNaoEncontrada, "Deletar já removida"); err != nil {
+		return err
+	}
+
+	// O código de uma categoria removida pode ser usado de novo, sem herdar
+	// as traduções da anterior.
+	recriada := *categorias[0]
+	recriada.Traducoes = map[string]string{}
+	if err := repo.Criar(ctx, &recriada); err != nil {
+		return fmt.Errorf("Criar com código de categoria removida: %w", err)
+	}
+	if obtida, err := repo.BuscarPorCodigo(ctx, "TESTE_B"); err != nil || obtida == nil || len(obtida.Traducoes) != 0 {
+		return fmt.Errorf("categoria recriada não deve ter as traduções da removida: %+v, %v", obtida, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package contrato
+
+import (
+	"context"
+	"fmt"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/internal/core/ports"
+)
+
+// VerificarClientes exercita um ClienteRepository vazio.
+func VerificarClientes(ctx context.Context, repo ports.ClienteRepository) error {
+	if cliente, err := repo.BuscarPorID(ctx, "inexistente"); err != nil || cliente != nil {
+		return fmt.Errorf("BuscarPorID de ID inexistente deve retornar nil, nil; obtido %v, %v", cliente, err)
+	}
+	if cliente, err := repo.BuscarPorCPF(ctx, gerarCPF("999999999")); err != nil || cliente != nil {
+		return fmt.Errorf("BuscarPorCPF de CPF inexistente deve retornar nil, nil; obtido %v, %v", cliente, err)
+	}
+
+	clientes := []*domain.Cliente{
+		{ID: "c-2", Nome: "Bruna", CPF: gerarCPF("111444777"), Email: "bruna@exemplo.com", Telefone: "11999990002", CreatedAt: instante(2), UpdatedAt: instante(2), Versao: 1},
+		{ID: "c-1", Nome: "Ana", CPF: gerarCPF("123456789"), Email: "ana@exemplo.com", Telefone: "11999990001", CreatedAt: instante(3), UpdatedAt: instante(3), Versao: 1},
+		{ID: "c-3", Nome: "Carlos", CPF: gerarCPF("987654321"), Email: "carlos@exemplo.com", Telefone: "11999990003", CreatedAt: instante(1), UpdatedAt: instante(1), Versao: 1},
+	}
+	for _, cliente := range clientes {
+		if err := repo.Criar(ctx, cliente); err != nil {
+			return fmt.Errorf("Criar %s: %w", cliente.ID, err)
+		}
+	}
+
+	duplicado := *clientes[0]
+	duplicado.ID = "c-4"
+	if err := esperarErro(repo.Criar(ctx, &duplicado), domain.ErrCPFDuplicado, "Criar com CPF repetido"); err != nil {
+		return err
+	}
+
+	obtido, err := repo.BuscarPorID(ctx, "c-1")
+	if err != nil || obtido == nil {
+		return fmt.Errorf("BuscarPorID: %v, %v", obtido, err)
+	}
+	if obtido.Nome != "Ana" || obtido.CPF != clientes[1].CPF || obtido.Email != "ana@exemplo.com" || !mesmoInstante(obtido.CreatedAt, instante(3)) || obtido.Versao != 1 {
+		return fmt.Errorf("BuscarPorID retornou dados diferentes dos gravados: %+v", obtido)
+	}
+
+	obtido, err = repo.BuscarPorCPF(ctx, clientes[2].CPF)
+	if err != nil || obtido == nil || obtido.ID != "c-3" {
+		return fmt.Errorf("BuscarPorCPF: %v, %v", obtido, err)
+	}
+
+	idCliente := func(c *domain.Cliente) string { return c.ID }
+	pagina, err := listarClientes(ctx, repo, domain.FiltroClientes{Paginacao: domain.Paginacao{Limite: 2}})
+	if err != nil {
+		return err
+	}
+	if err := mesmaSequencia(ids(pagina.Itens, idCliente), "c-1", "c-2"); err != nil {
+		return fmt.Errorf("Listar por nome, primeira página: %w", err)
+	}
+	if pagina.ProximoCursor == "" {
+		return fmt.Errorf("Listar deve indicar a próxima página")
+	}
+	pagina, err = listarClientes(ctx, repo, domain.FiltroClientes{Paginacao: domain.Paginacao{Limite: 2, Cursor: pagina.ProximoCursor}})
+	if err != nil {
+		return err
+	}
+	if err := mesmaSequencia(ids(pagina.Itens, idCliente), "c-3"); err != nil {
+		return fmt.Errorf("Listar por nome, segunda página: %w", err)
+	}
+	if pagina.ProximoCursor != "" {
+		return fmt.Errorf("Listar não deve indicar próxima página na última página")
+	}
+
+	pagina, err = listarClientes(ctx, repo, domain.FiltroClientes{Paginacao: domain.Paginacao{Ordenacao: domain.OrdenarClientesPorCriacao, Decrescente: true}})
+	if err != nil {
+		return err
+	}
+	if err := mesmaSequencia(ids(pagina.Itens, idCliente), "c-1", "c-2", "c-3"); err != nil {
+		return fmt.Errorf("Listar por criação decrescente: %w", err)
+	}
+
+	pagina, err = listarClientes(ctx, repo, domain.FiltroClientes{Nome: "ar"})
+	if err != nil {
+		return err
+	}
+	if err := mesmaSequencia(ids(pagina.Itens, idCliente), "c-3"); err != nil {
+		return fmt.Errorf("Listar filtrando por nome: %w", err)
+	}
+
+	atualizado := *clientes[1]
+	atualizado.Nome = "Ana Maria"
+	atualizado.UpdatedAt = instante(10)
+	if err := repo.Atualizar(ctx, &atualizado); err != nil {
+		return fmt.Errorf("Atualizar: %w", err)
+	}
+	obtido, err = repo.BuscarPorID(ctx, "c-1")
+	if err != nil || obtido == nil || obtido.Nome != "Ana Maria" || !mesmoInstante(obtido.UpdatedAt, instante(10)) {
+		return fmt.Errorf("Atualizar não persistiu as alterações: %+v, %v", obtido, err)
+	}
+	if atualizado.Versao != 2 || obtido.Versao != 2 {
+		return fmt.Errorf("Atualizar deve incrementar a versão para 2; obtido %d no argumento e %d gravada", atualizado.Versao, obtido.Versao)
+	}
+
+	desatualizado := *clientes[1]
+	desatualizado.Nome = "Ana Paula"
+	if err := esperarErro(repo.Atualizar(ctx, &desatualizado), domain.ErrVersaoDesatualizada, "Atualizar com versão antiga"); err != nil {
+		return err
+	}
+	if obtido, err := repo.BuscarPorID(ctx, "c-1"); err != nil || obtido == nil || obtido.Nome != "Ana Maria" {
+		return fmt.Errorf("Atualizar com versão antiga não deve alterar o registro: %+v, %v", obtido, err)
+	}
+
+	atualizado.CPF = clientes[0].CPF
+	if err := esperarErro(repo.Atualizar(ctx, &atualizado), domain.ErrCPFDuplicado, "Atualizar para CPF de outro cliente"); err != nil {
+		return err
+	}
+
+	inexistente := *clientes[0]
+	inexistente.ID = "inexistente"
+	inexistente.CPF = gerarCPF("555666777")
+	if err := esperarErro(repo.Atualizar(ctx, &inexistente), domain.ErrClienteNaoEncontrado, "Atualizar inexistente"); err != nil {
+		return err
+	}
+
+	antesDeDeletar, err := repo.BuscarPorID(ctx, "c-2")
+	if err != nil || antesDeDeletar == nil {
+		return fmt.Errorf("BuscarPorID antes de Deletar: %v, %v", antesDeDeletar, err)
+	}
+	if err := repo.Deletar(ctx, "c-2"); err != nil {
+		return fmt.Errorf("Deletar: %w", err)
+	}
+	if obtido, err := repo.BuscarPorID(ctx, "c-2"); err != nil || obtido != nil {
+		return fmt.Errorf("cliente deletado ainda é encontrado: %v, %v", obtido, err)
+	}
+	if err := esperarErro(repo.Atualizar(ctx, antesDeDeletar), domain.ErrClienteNaoEncontrado, "Atualizar cliente removido"); err != nil {
+		return err
+	}
+	if err := esperarErro(repo.Deletar(ctx, "c-2"), domain.ErrClienteNaoEncontrado, "Deletar já removido"); err != nil {
+		return err
+	}
+	if err := esperarErro(repo.Deletar(ctx, "inexistente"), domain.ErrClienteNaoEncontrado, "Deletar inexistente"); err != nil {
+		return err
+	}
+
+	ativos, err := listarClientes(ctx, repo, domain.FiltroClientes{})
+	if err != nil {
+		return err
+	}
+	for _, cliente := range ativos.Itens {
+		if cliente.ID == "c-2" {
+			return fmt.Errorf("Listar não deve incluir clientes removidos")
+		}
+	}
+	removidos, err := listarClientes(ctx, repo, domain.FiltroClientes{Removidos: true})
+	if err != nil {
+		return err
+	}
+	if err := mesmaSequencia(ids(removidos.Itens, idCliente), "c-2"); err != nil {
+		return fmt.Errorf("Listar removidos: %w", err)
+	}
+	if removidos.Itens[0].DeletedAt == nil || removidos.Itens[0].Versao != 2 {
+		return fmt.Errorf("Deletar deve preencher DeletedAt e incrementar a versão: %+v", removidos.Itens[0])
+	}
+
+	if err := repo.Restaurar(ctx, "c-2"); err != nil {
+		return fmt.Errorf("Restaurar: %w", err)
+	}
+	obtido, err = repo.BuscarPorID(ctx, "c-2")
+	if err != nil || obtido == nil || obtido.DeletedAt != nil || obtido.Versao != 3 {
+		return fmt.Errorf("Restaurar deve reativar o cliente na versão 3: %+v, %v", obtido, err)
+	}
+	if err := esperarErro(repo.Restaurar(ctx, "c-2"), domain.ErrClienteNaoEncontrado, "Restaurar cliente ativo"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func listarClientes(ctx context.Context, repo ports.ClienteRepository, filtro domain.FiltroClientes) (*domain.Pagina[*domain.Cliente], error) {
+	if err := filtro.Validar(); err != nil {
+		return nil, err
+	}
+	pagina, err := repo.Listar(ctx, filtro)
+	if err != nil {
+		return nil, fmt.Errorf("Listar: %w", err)
+	}
+	return pagina, nil
+}
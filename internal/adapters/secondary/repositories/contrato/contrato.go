@@ -0,0 +1,98 @@
+// Package contrato verifica se uma implementação das portas de repositório
+// segue a semântica esperada pelos serviços: nil quando o registro não existe,
+// CPF único, erros de domínio em atualizações e remoções, controle de versão
+// nas atualizações, remoção lógica com restauração e ordenação e paginação
+// iguais às do adaptador MySQL.
+//
+// As verificações seguem o modelo de testing/fstest: recebem repositórios
+// vazios, retornam o primeiro desvio encontrado e podem ser usadas por
+// qualquer adaptador, seja em testes ou em uma checagem manual.
+package contrato
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"soat-fiap/internal/core/ports"
+)
+
+// Repositorios agrupa as implementações a verificar. Todas devem estar vazias,
+// exceto Categorias, que deve ter só as categorias padrão criadas pela
+// migração.
+type Repositorios struct {
+	Clientes   ports.ClienteRepository
+	Produtos   ports.ProdutoRepository
+	Categorias ports.CategoriaRepository
+	Pedidos    ports.PedidoRepository
+}
+
+// Verificar executa todas as verificações de contrato.
+func Verificar(ctx context.Context, repos Repositorios) error {
+	if err := VerificarClientes(ctx, repos.Clientes); err != nil {
+		return fmt.Errorf("ClienteRepository: %w", err)
+	}
+	if err := VerificarProdutos(ctx, repos.Produtos); err != nil {
+		return fmt.Errorf("ProdutoRepository: %w", err)
+	}
+	if err := VerificarCategorias(ctx, repos.Categorias); err != nil {
+		return fmt.Errorf("CategoriaRepository: %w", err)
+	}
+	if err := VerificarPedidos(ctx, repos.Pedidos); err != nil {
+		return fmt.Errorf("PedidoRepository: %w", err)
+	}
+	if err := VerificarRemocaoProdutoEmPedido(ctx, repos.Produtos, repos.Pedidos); err != nil {
+		return fmt.Errorf("ProdutoRepository com PedidoRepository: %w", err)
+	}
+	return nil
+}
+
+// instante devolve um horário fixo, sem frações de segundo, já que nem todos
+// os bancos guardam a mesma precisão.
+func instante(minutos int) time.Time {
+	return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(minutos) * time.Minute)
+}
+
+func mesmoInstante(a, b time.Time) bool {
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
+// gerarCPF completa os nove dígitos informados com os dígitos verificadores.
+func gerarCPF(base string) string {
+	digito := func(cpf string) byte {
+		soma := 0
+		for i := range cpf {
+			soma += int(cpf[i]-'0') * (len(cpf) + 1 - i)
+		}
+		resto := soma % 11
+		if resto < 2 {
+			return '0'
+		}
+		return byte('0' + 11 - resto)
+	}
+	cpf := base + string(digito(base))
+	return cpf + string(digito(cpf))
+}
+
+func esperarErro(err, alvo error, operacao string) error {
+	if !errors.Is(err, alvo) {
+		return fmt.Errorf("%s: esperado erro %q, obtido %v", operacao, alvo, err)
+	}
+	return nil
+}
+
+func ids[T any](itens []T, id func(T) string) []string {
+	resultado := make([]string, len(itens))
+	for i, item := range itens {
+		resultado[i] = id(item)
+	}
+	return resultado
+}
+
+func mesmaSequencia(obtido []string, esperado ...string) error {
+	if fmt.Sprint(obtido) != fmt.Sprint(esperado) {
+		return fmt.Errorf("esperado %v, obtido %v", esperado, obtido)
+	}
+	return nil
+}
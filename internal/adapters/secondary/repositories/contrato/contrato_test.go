@@ -0,0 +1,127 @@
+package contrato_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+
+	"soat-fiap/internal/adapters/secondary/repositories"
+	"soat-fiap/internal/adapters/secondary/repositories/contrato"
+	"soat-fiap/internal/adapters/secondary/repositories/memoria"
+	"soat-fiap/internal/adapters/secondary/repositories/postgres"
+	"soat-fiap/internal/adapters/secondary/repositories/sqlite"
+	"soat-fiap/pkg/database"
+)
+
+// Os adaptadores com servidor só são verificados quando a variável com a DSN
+// está definida. O banco informado é zerado (todas as migrações revertidas e
+// aplicadas de novo), então deve ser descartável.
+const (
+	variavelMySQL    = "CONTRATO_MYSQL_DSN"
+	variavelPostgres = "CONTRATO_POSTGRES_DSN"
+)
+
+func verificar(t *testing.T, repos contrato.Repositorios) {
+	t.Helper()
+	if err := contrato.Verificar(context.Background(), repos); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// migrar deixa o banco vazio, só com as categorias padrão criadas pelas
+// migrações.
+func migrar(t *testing.T, db *sql.DB, driver string) {
+	t.Helper()
+	migrador, err := database.NovoMigrador(db, driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrador.Para(context.Background(), 0); err != nil {
+		t.Fatalf("reverter migrações: %v", err)
+	}
+	if err := migrador.Subir(context.Background()); err != nil {
+		t.Fatalf("aplicar migrações: %v", err)
+	}
+}
+
+func TestMemoria(t *testing.T) {
+	pedidos := memoria.NovoPedidoRepository()
+	produtos := memoria.NovoProdutoRepository(pedidos)
+	verificar(t, contrato.Repositorios{
+		Clientes:   memoria.NovoClienteRepository(),
+		Produtos:   produtos,
+		Categorias: memoria.NovoCategoriaRepository(produtos),
+		Pedidos:    pedidos,
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	db, err := database.Conectar(database.Conexao{
+		Driver:  database.DriverSQLite,
+		Caminho: filepath.Join(t.TempDir(), "contrato.db"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	migrar(t, db, database.DriverSQLite)
+	verificar(t, contrato.Repositorios{
+		Clientes:   sqlite.NovoClienteRepository(db),
+		Produtos:   sqlite.NovoProdutoRepository(db),
+		Categorias: sqlite.NovoCategoriaRepository(db),
+		Pedidos:    sqlite.NovoPedidoRepository(db),
+	})
+}
+
+func TestMySQL(t *testing.T) {
+	dsn := os.Getenv(variavelMySQL)
+	if dsn == "" {
+		t.Skipf("defina %s para verificar o adaptador MySQL", variavelMySQL)
+	}
+	// Os repositórios dependem de parseTime e de clientFoundRows, como na
+	// DSN montada por database.Conectar.
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("%s inválida: %v", variavelMySQL, err)
+	}
+	cfg.ParseTime = true
+	cfg.ClientFoundRows = true
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	migrar(t, db, database.DriverMySQL)
+	verificar(t, contrato.Repositorios{
+		Clientes:   repositories.NovoClienteRepository(db),
+		Produtos:   repositories.NovoProdutoRepository(db),
+		Categorias: repositories.NovoCategoriaRepository(db),
+		Pedidos:    repositories.NovoPedidoRepository(db),
+	})
+}
+
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv(variavelPostgres)
+	if dsn == "" {
+		t.Skipf("defina %s para verificar o adaptador PostgreSQL", variavelPostgres)
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	migrar(t, db, database.DriverPostgres)
+	verificar(t, contrato.Repositorios{
+		Clientes:   postgres.NovoClienteRepository(db),
+		Produtos:   postgres.NovoProdutoRepository(db),
+		Categorias: postgres.NovoCategoriaRepository(db),
+		Pedidos:    postgres.NovoPedidoRepository(db),
+	})
+}
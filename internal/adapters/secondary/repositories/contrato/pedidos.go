@@ -0,0 +1,144 @@
+package contrato
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/internal/core/ports"
+)
+
+// VerificarPedidos exercita um PedidoRepository vazio.
+func VerificarPedidos(ctx context.Context, repo ports.PedidoRepository) error {
+	if pedido, err := repo.BuscarPorID(ctx, "inexistente"); err != nil || pedido != nil {
+		return fmt.Errorf("BuscarPorID de ID inexistente deve retornar nil, nil; obtido %v, %v", pedido, err)
+	}
+
+	clienteA, clienteB := "cliente-a", "cliente-b"
+	pedidos := []*domain.Pedido{
+		{ID: "o-1", ClienteID: &clienteA, Status: domain.StatusRecebido, CreatedAt: instante(1), UpdatedAt: instante(1), Versao: 1, Itens: []domain.ItemPedido{
+			{ProdutoID: "p-2", Nome: "Batata Frita", Preco: 12.5, Quantidade: 2, Observacao: "sem sal"},
+			{ProdutoID: "p-1", Nome: "X-Burguer", Preco: 25.9, Quantidade: 1},
+		}},
+		{ID: "o-2", Status: domain.StatusEmPreparacao, CreatedAt: instante(2), UpdatedAt: instante(2), Versao: 1, Itens: []domain.ItemPedido{
+			{ProdutoID: "p-4", Nome: "Suco", Preco: 12.5, Quantidade: 1},
+		}},
+		{ID: "o-3", ClienteID: &clienteB, Status: domain.StatusRecebido, CreatedAt: instante(3), UpdatedAt: instante(3), Versao: 1, Itens: []domain.ItemPedido{
+			{ProdutoID: "p-1", Nome: "X-Burguer", Preco: 25.9, Quantidade: 3},
+		}},
+		{ID: "o-4", ClienteID: &clienteA, Status: domain.StatusPronto, CreatedAt: instante(4), UpdatedAt: instante(4), Versao: 1, Itens: []domain.ItemPedido{
+			{ProdutoID: "p-4", Nome: "Suco", Preco: 12.5, Quantidade: 2},
+		}},
+	}
+	for _, pedido := range pedidos {
+		pedido.CalcularValorTotal()
+		if err := repo.Criar(ctx, pedido); err != nil {
+			return fmt.Errorf("Criar %s: %w", pedido.ID, err)
+		}
+	}
+
+	obtido, err := repo.BuscarPorID(ctx, "o-1")
+	if err != nil || obtido == nil {
+		return fmt.Errorf("BuscarPorID: %v, %v", obtido, err)
+	}
+	if obtido.ClienteID == nil || *obtido.ClienteID != clienteA || obtido.ValorTotal != 50.9 || obtido.Status != domain.StatusRecebido || !mesmoInstante(obtido.CreatedAt, instante(1)) {
+		return fmt.Errorf("BuscarPorID retornou dados diferentes dos gravados: %+v", obtido)
+	}
+	if len(obtido.Itens) != 2 || obtido.Itens[0].ProdutoID != "p-1" || obtido.Itens[1].Observacao != "sem sal" || obtido.Itens[1].Quantidade != 2 {
+		return fmt.Errorf("BuscarPorID deve retornar os itens ordenados por produto: %+v", obtido.Itens)
+	}
+
+	semCliente, err := repo.BuscarPorID(ctx, "o-2")
+	if err != nil || semCliente == nil || semCliente.ClienteID != nil {
+		return fmt.Errorf("pedido sem cliente deve ter ClienteID nil: %+v, %v", semCliente, err)
+	}
+
+	idPedido := func(p *domain.Pedido) string { return p.ID }
+	casos := []struct {
+		descricao string
+		filtro    domain.FiltroPedidos
+		esperado  []string
+	}{
+		{"mais recentes primeiro", domain.FiltroPedidos{}, []string{"o-4", "o-3", "o-2", "o-1"}},
+		{"por status, mais antigos primeiro", domain.FiltroPedidos{Status: []domain.StatusPedido{domain.StatusRecebido, domain.StatusPronto}}, []string{"o-1", "o-3", "o-4"}},
+		{"por status e cliente", domain.FiltroPedidos{Status: []domain.StatusPedido{domain.StatusRecebido, domain.StatusPronto}, ClienteID: clienteA}, []string{"o-1", "o-4"}},
+		{"por período", domain.FiltroPedidos{De: ptr(instante(2)), Ate: ptr(instante(4))}, []string{"o-3", "o-2"}},
+		{"por valor", domain.FiltroPedidos{Paginacao: domain.Paginacao{Ordenacao: domain.OrdenarPedidosPorValor}}, []string{"o-2", "o-4", "o-1", "o-3"}},
+	}
+	for _, caso := range casos {
+		pagina, err := listarPedidos(ctx, repo, caso.filtro)
+		if err != nil {
+			return err
+		}
+		if err := mesmaSequencia(ids(pagina.Itens, idPedido), caso.esperado...); err != nil {
+			return fmt.Errorf("Listar %s: %w", caso.descricao, err)
+		}
+		for _, pedido := range pagina.Itens {
+			if len(pedido.Itens) == 0 {
+				return fmt.Errorf("Listar %s: pedido %s sem itens", caso.descricao, pedido.ID)
+			}
+		}
+	}
+
+	filtro := domain.FiltroPedidos{Paginacao: domain.Paginacao{Limite: 3}}
+	pagina, err := listarPedidos(ctx, repo, filtro)
+	if err != nil {
+		return err
+	}
+	filtro.Cursor = pagina.ProximoCursor
+	pagina, err = listarPedidos(ctx, repo, filtro)
+	if err != nil {
+		return err
+	}
+	if err := mesmaSequencia(ids(pagina.Itens, idPedido), "o-1"); err != nil {
+		return fmt.Errorf("Listar segunda página: %w", err)
+	}
+
+	atualizado := *obtido
+	atualizado.AtualizarStatus(domain.StatusEmPreparacao)
+	atualizado.UpdatedAt = instante(10)
+	if err := repo.Atualizar(ctx, &atualizado); err != nil {
+		return fmt.Errorf("Atualizar: %w", err)
+	}
+	obtido, err = repo.BuscarPorID(ctx, "o-1")
+	if err != nil || obtido == nil || obtido.Status != domain.StatusEmPreparacao || !mesmoInstante(obtido.UpdatedAt, instante(10)) || len(obtido.Itens) != 2 {
+		return fmt.Errorf("Atualizar não persistiu o status: %+v, %v", obtido, err)
+	}
+	if atualizado.Versao != 2 || obtido.Versao != 2 {
+		return fmt.Errorf("Atualizar deve incrementar a versão para 2; obtido %d no argumento e %d gravada", atualizado.Versao, obtido.Versao)
+	}
+
+	contagem, err := repo.ContarPorStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("ContarPorStatus: %w", err)
+	}
+	esperada := map[domain.StatusPedido]int{domain.StatusRecebido: 1, domain.StatusEmPreparacao: 2, domain.StatusPronto: 1}
+	if !maps.Equal(contagem, esperada) {
+		return fmt.Errorf("ContarPorStatus: esperado %v, obtido %v", esperada, contagem)
+	}
+
+	desatualizado := *pedidos[0]
+	desatualizado.AtualizarStatus(domain.StatusPronto)
+	if err := esperarErro(repo.Atualizar(ctx, &desatualizado), domain.ErrVersaoDesatualizada, "Atualizar com versão antiga"); err != nil {
+		return err
+	}
+
+	atualizado.ID = "inexistente"
+	if err := esperarErro(repo.Atualizar(ctx, &atualizado), domain.ErrPedidoNaoEncontrado, "Atualizar inexistente"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func listarPedidos(ctx context.Context, repo ports.PedidoRepository, filtro domain.FiltroPedidos) (*domain.Pagina[*domain.Pedido], error) {
+	if err := filtro.Validar(); err != nil {
+		return nil, err
+	}
+	pagina, err := repo.Listar(ctx, filtro)
+	if err != nil {
+		return nil, fmt.Errorf("Listar: %w", err)
+	}
+	return pagina, nil
+}
@@ -0,0 +1,353 @@
+package contrato
+
+import (
+	"context"
+	"fmt"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/internal/core/ports"
+)
+
+// VerificarProdutos exercita um ProdutoRepository vazio.
+func VerificarProdutos(ctx context.Context, repo ports.ProdutoRepository) error {
+	if produto, err := repo.BuscarPorID(ctx, "inexistente"); err != nil || produto != nil {
+		return fmt.Errorf("BuscarPorID de ID inexistente deve retornar nil, nil; obtido %v, %v", produto, err)
+	}
+
+	produtos := []*domain.Produto{
+		{ID: "p-1", Nome: "X-Burguer", Descricao: "Pão, carne e queijo", Preco: 25.9, Categoria: domain.Categoria("LANCHE"), Disponivel: true, CreatedAt: instante(1), UpdatedAt: instante(1), Versao: 1},
+		{ID: "p-2", Nome: "Batata Frita", Descricao: "Porção média", Preco: 12.5, Categoria: domain.Categoria("ACOMPANHAMENTO"), Disponivel: true, CreatedAt: instante(2), UpdatedAt: instante(2), Versao: 1},
+		{ID: "p-3", Nome: "Refrigerante", Descricao: "Lata 350ml", Preco: 7, Categoria: domain.Categoria("BEBIDA"), Disponivel: false, CreatedAt: instante(3), UpdatedAt: instante(3), Versao: 1},
+		{ID: "p-4", Nome: "Suco", Descricao: "Laranja 500ml", Preco: 12.5, Categoria: domain.Categoria("BEBIDA"), Disponivel: true, CreatedAt: instante(4), UpdatedAt: instante(4), Versao: 1},
+	}
+	for _, produto := range produtos {
+		if err := repo.Criar(ctx, produto); err != nil {
+			return fmt.Errorf("Criar %s: %w", produto.ID, err)
+		}
+	}
+
+	obtido, err := repo.BuscarPorID(ctx, "p-1")
+	if err != nil || obtido == nil {
+		return fmt.Errorf("BuscarPorID: %v, %v", obtido, err)
+	}
+	if obtido.Nome != "X-Burguer" || obtido.Preco != 25.9 || obtido.Categoria != domain.Categoria("LANCHE") || !obtido.Disponivel || !mesmoInstante(obtido.CreatedAt, instante(1)) || obtido.Versao != 1 {
+		return fmt.Errorf("BuscarPorID retornou dados diferentes dos gravados: %+v", obtido)
+	}
+
+	idProduto := func(p *domain.Produto) string { return p.ID }
+	casos := []struct {
+		descricao string
+		filtro    domain.FiltroProdutos
+		esperado  []string
+	}{
+		{"por nome", domain.FiltroProdutos{}, []string{"p-2", "p-3", "p-4", "p-1"}},
+		{"por preço decrescente, desempate por ID", domain.FiltroProdutos{Paginacao: domain.Paginacao{Ordenacao: domain.OrdenarProdutosPorPreco, Decrescente: true}}, []string{"p-1", "p-4", "p-2", "p-3"}},
+		{"por categoria", domain.FiltroProdutos{Categoria: domain.Categoria("BEBIDA")}, []string{"p-3", "p-4"}},
+		{"por faixa de preço", domain.FiltroProdutos{PrecoMin: ptr(10.0), PrecoMax: ptr(20.0)}, []string{"p-2", "p-4"}},
+		{"por disponibilidade", domain.FiltroProdutos{Disponivel: ptr(false)}, []string{"p-3"}},
+	}
+	for _, caso := range casos {
+		pagina, err := listarProdutos(ctx, repo, caso.filtro)
+		if err != nil {
+			return err
+		}
+		if err := mesmaSequencia(ids(pagina.Itens, idProduto), caso.esperado...); err != nil {
+			return fmt.Errorf("Listar %s: %w", caso.descricao, err)
+		}
+	}
+
+	filtro := domain.FiltroProdutos{Paginacao: domain.Paginacao{Limite: 1, Ordenacao: domain.OrdenarProdutosPorPreco}}
+	var paginados []string
+	for {
+		pagina, err := listarProdutos(ctx, repo, filtro)
+		if err != nil {
+			return err
+		}
+		paginados = append(paginados, ids(pagina.Itens, idProduto)...)
+		if pagina.ProximoCursor == "" {
+			break
+		}
+		filtro.Cursor = pagina.ProximoCursor
+	}
+	if err := mesmaSequencia(paginados, "p-3", "p-2", "p-4", "p-1"); err != nil {
+		return fmt.Errorf("Listar paginando por preço com empate: %w", err)
+	}
+
+	atualizado := *produtos[0]
+	atualizado.Preco = 27.5
+	atualizado.Disponivel = false
+	atualizado.UpdatedAt = instante(10)
+	if err := repo.Atualizar(ctx, &atualizado); err != nil {
+		return fmt.Errorf("Atualizar: %w", err)
+	}
+	obtido, err = repo.BuscarPorID(ctx, "p-1")
+	if err != nil || obtido == nil || obtido.Preco != 27.5 || obtido.Disponivel || !mesmoInstante(obtido.UpdatedAt, instante(10)) {
+		return fmt.Errorf("Atualizar não persistiu as alterações: %+v, %v", obtido, err)
+	}
+	if atualizado.Versao != 2 || obtido.Versao != 2 {
+		return fmt.Errorf("Atualizar deve incrementar a versão para 2; obtido %d no argumento e %d gravada", atualizado.Versao, obtido.Versao)
+	}
+
+	desatualizado := *produtos[0]
+	desatualizado.Preco = 30
+	if err := esperarErro(repo.Atualizar(ctx, &desatualizado), domain.ErrVersaoDesatualizada, "Atualizar com versão antiga"); err != nil {
+		return err
+	}
+	if obtido, err := repo.BuscarPorID(ctx, "p-1"); err != nil || obtido == nil || obtido.Preco != 27.5 {
+		return fmt.Errorf("Atualizar com versão antiga não deve alterar o registro: %+v, %v", obtido, err)
+	}
+
+	atualizado.ID = "inexistente"
+	if err := esperarErro(repo.Atualizar(ctx, &atualizado), domain.ErrProdutoNaoEncontrado, "Atualizar inexistente"); err != nil {
+		return err
+	}
+
+	if err := verificarPrecos(ctx, repo); err != nil {
+		return err
+	}
+	if err := verificarImagem(ctx, repo); err != nil {
+		return err
+	}
+
+	antesDeDeletar, err := repo.BuscarPorID(ctx, "p-3")
+	if err != nil || antesDeDeletar == nil {
+		return fmt.Errorf("BuscarPorID antes de Deletar: %v, %v", antesDeDeletar, err)
+	}
+	if err := repo.Deletar(ctx, "p-3"); err != nil {
+		return fmt.Errorf("Deletar: %w", err)
+	}
+	if obtido, err := repo.BuscarPorID(ctx, "p-3"); err != nil || obtido != nil {
+		return fmt.Errorf("produto deletado ainda é encontrado: %v, %v", obtido, err)
+	}
+	// Uma alteração que perdeu a corrida para a remoção vê o produto como
+	// inexistente, não como alterado por outra requisição.
+	if err := esperarErro(repo.Atualizar(ctx, antesDeDeletar), domain.ErrProdutoNaoEncontrado, "Atualizar produto removido"); err != nil {
+		return err
+	}
+	if err := esperarErro(repo.AtualizarImagem(ctx, antesDeDeletar), domain.ErrProdutoNaoEncontrado, "AtualizarImagem de produto removido"); err != nil {
+		return err
+	}
+	if err := esperarErro(repo.Deletar(ctx, "p-3"), domain.ErrProdutoNaoEncontrado, "Deletar já removido"); err != nil {
+		return err
+	}
+	if err := esperarErro(repo.Deletar(ctx, "inexistente"), domain.ErrProdutoNaoEncontrado, "Deletar inexistente"); err != nil {
+		return err
+	}
+
+	ativos, err := listarProdutos(ctx, repo, domain.FiltroProdutos{})
+	if err != nil {
+		return err
+	}
+	for _, produto := range ativos.Itens {
+		if produto.ID == "p-3" {
+			return fmt.Errorf("Listar não deve incluir produtos removidos")
+		}
+	}
+	removidos, err := listarProdutos(ctx, repo, domain.FiltroProdutos{Removidos: true})
+	if err != nil {
+		return err
+	}
+	if err := mesmaSequencia(ids(removidos.Itens, idProduto), "p-3"); err != nil {
+		return fmt.Errorf("Listar removidos: %w", err)
+	}
+	if removidos.Itens[0].DeletedAt == nil || removidos.Itens[0].Versao != 2 {
+		return fmt.Errorf("Deletar deve preencher DeletedAt e incrementar a versão: %+v", removidos.Itens[0])
+	}
+
+	if err := repo.Restaurar(ctx, "p-3"); err != nil {
+		return fmt.Errorf("Restaurar: %w", err)
+	}
+	obtido, err = repo.BuscarPorID(ctx, "p-3")
+	if err != nil || obtido == nil || obtido.DeletedAt != nil || obtido.Versao != 3 {
+		return fmt.Errorf("Restaurar deve reativar o produto na versão 3: %+v, %v", obtido, err)
+	}
+	if err := esperarErro(repo.Restaurar(ctx, "p-3"), domain.ErrProdutoNaoEncontrado, "Restaurar produto ativo"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// verificarImagem usa p-4, que ainda está na versão 1 e sem imagem.
+func verificarImagem(ctx context.Context, repo ports.ProdutoRepository) error {
+	produto, err := repo.BuscarPorID(ctx, "p-4")
+	if err != nil || produto == nil || produto.Imagem != nil {
+		return fmt.Errorf("produto sem imagem deve ter Imagem nil: %+v, %v", produto, err)
+	}
+
+	produto.Imagem = &domain.Imagem{Chave: "produtos/p-4/a.png"}
+	produto.UpdatedAt = instante(12)
+	if err := repo.AtualizarImagem(ctx, produto); err != nil {
+		return fmt.Errorf("AtualizarImagem: %w", err)
+	}
+	obtido, err := repo.BuscarPorID(ctx, "p-4")
+	if err != nil || obtido == nil || obtido.Imagem == nil || obtido.Imagem.Chave != "produtos/p-4/a.png" || !mesmoInstante(obtido.UpdatedAt, instante(12)) {
+		return fmt.Errorf("AtualizarImagem não persistiu a imagem: %+v, %v", obtido, err)
+	}
+	if produto.Versao != 2 || obtido.Versao != 2 {
+		return fmt.Errorf("AtualizarImagem deve incrementar a versão para 2; obtido %d no argumento e %d gravada", produto.Versao, obtido.Versao)
+	}
+
+	obtido.Nome = "Suco de laranja"
+	obtido.Imagem = nil
+	if err := repo.Atualizar(ctx, obtido); err != nil {
+		return fmt.Errorf("Atualizar: %w", err)
+	}
+	if obtido, err := repo.BuscarPorID(ctx, "p-4"); err != nil || obtido == nil || obtido.Imagem == nil {
+		return fmt.Errorf("Atualizar não deve alterar a imagem: %+v, %v", obtido, err)
+	}
+
+	desatualizado := *produto
+	desatualizado.Imagem = &domain.Imagem{Chave: "produtos/p-4/b.png"}
+	if err := esperarErro(repo.AtualizarImagem(ctx, &desatualizado), domain.ErrVersaoDesatualizada, "AtualizarImagem com versão antiga"); err != nil {
+		return err
+	}
+	desatualizado.ID = "inexistente"
+	if err := esperarErro(repo.AtualizarImagem(ctx, &desatualizado), domain.ErrProdutoNaoEncontrado, "AtualizarImagem inexistente"); err != nil {
+		return err
+	}
+
+	obtido.Imagem = nil
+	if err := repo.AtualizarImagem(ctx, obtido); err != nil {
+		return fmt.Errorf("AtualizarImagem sem imagem: %w", err)
+	}
+	if obtido, err := repo.BuscarPorID(ctx, "p-4"); err != nil || obtido == nil || obtido.Imagem != nil || obtido.Versao != 4 {
+		return fmt.Errorf("AtualizarImagem com Imagem nil deve remover a imagem na versão 4: %+v, %v", obtido, err)
+	}
+
+	return nil
+}
+
+// verificarPrecos parte do estado deixado pelas atualizações: p-1 cadastrado
+// a 25,9 em instante(1) e alterado para 27,5 em instante(10), na versão 2.
+func verificarPrecos(ctx context.Context, repo ports.ProdutoRepository) error {
+	valor := func(p *domain.PrecoProduto) string { return fmt.Sprint(p.Preco) }
+
+	historico, err := repo.ListarPrecos(ctx, "p-1")
+	if err != nil {
+		return fmt.Errorf("ListarPrecos: %w", err)
+	}
+	if err := mesmaSequencia(ids(historico, valor), "27.5", "25.9"); err != nil {
+		return fmt.Errorf("Criar e Atualizar devem registrar o histórico de preços: %w", err)
+	}
+	if !mesmoInstante(historico[0].VigenteDesde, instante(10)) || !mesmoInstante(historico[1].VigenteDesde, instante(1)) || historico[0].ID == 0 {
+		return fmt.Errorf("histórico com vigência diferente da gravada: %+v, %+v", historico[0], historico[1])
+	}
+	mesmoPreco, err := repo.BuscarPorID(ctx, "p-2")
+	if err != nil || mesmoPreco == nil {
+		return fmt.Errorf("BuscarPorID: %v, %v", mesmoPreco, err)
+	}
+	mesmoPreco.Disponivel = false
+	if err := repo.Atualizar(ctx, mesmoPreco); err != nil {
+		return fmt.Errorf("Atualizar: %w", err)
+	}
+	if historico, err := repo.ListarPrecos(ctx, "p-2"); err != nil || len(historico) != 1 {
+		return fmt.Errorf("Atualizar sem mudança de preço não deve registrar histórico: %v, %v", historico, err)
+	}
+
+	agendado := &domain.PrecoProduto{ProdutoID: "p-1", Preco: 30, VigenteDesde: instante(20), CreatedAt: instante(11)}
+	if err := repo.AgendarPreco(ctx, agendado); err != nil || agendado.ID == 0 {
+		return fmt.Errorf("AgendarPreco deve preencher o ID: %d, %v", agendado.ID, err)
+	}
+	for _, caso := range []struct {
+		em       int
+		esperado float64
+	}{{0, 0}, {1, 25.9}, {15, 27.5}, {20, 30}} {
+		preco, err := repo.PrecoVigente(ctx, "p-1", instante(caso.em))
+		if err != nil {
+			return fmt.Errorf("PrecoVigente: %w", err)
+		}
+		obtido := 0.0
+		if preco != nil {
+			obtido = preco.Preco
+		}
+		if obtido != caso.esperado {
+			return fmt.Errorf("PrecoVigente em instante(%d): esperado %v, obtido %v", caso.em, caso.esperado, obtido)
+		}
+	}
+
+	if alterados, err := repo.AplicarPrecosVigentes(ctx, instante(15)); err != nil || alterados != 0 {
+		return fmt.Errorf("AplicarPrecosVigentes antes do agendamento não deve alterar produtos: %d, %v", alterados, err)
+	}
+	if alterados, err := repo.AplicarPrecosVigentes(ctx, instante(20)); err != nil || alterados != 1 {
+		return fmt.Errorf("AplicarPrecosVigentes deve alterar só o produto agendado: %d, %v", alterados, err)
+	}
+	obtido, err := repo.BuscarPorID(ctx, "p-1")
+	if err != nil || obtido == nil || obtido.Preco != 30 || obtido.Versao != 3 || !mesmoInstante(obtido.UpdatedAt, instante(20)) {
+		return fmt.Errorf("AplicarPrecosVigentes deve gravar o preço e incrementar a versão: %+v, %v", obtido, err)
+	}
+	if historico, err := repo.ListarPrecos(ctx, "p-1"); err != nil || len(historico) != 3 {
+		return fmt.Errorf("AplicarPrecosVigentes não deve registrar histórico: %v, %v", historico, err)
+	}
+
+	futuro := &domain.PrecoProduto{ProdutoID: "p-1", Preco: 35, VigenteDesde: instante(30), CreatedAt: instante(21)}
+	if err := repo.AgendarPreco(ctx, futuro); err != nil {
+		return fmt.Errorf("AgendarPreco: %w", err)
+	}
+	if err := esperarErro(repo.CancelarPrecoAgendado(ctx, "p-2", futuro.ID, instante(25)), domain.ErrPrecoAgendadoNaoEncontrado, "CancelarPrecoAgendado de outro produto"); err != nil {
+		return err
+	}
+	if err := esperarErro(repo.CancelarPrecoAgendado(ctx, "p-1", agendado.ID, instante(25)), domain.ErrPrecoAgendadoNaoEncontrado, "CancelarPrecoAgendado já vigente"); err != nil {
+		return err
+	}
+	if err := repo.CancelarPrecoAgendado(ctx, "p-1", futuro.ID, instante(25)); err != nil {
+		return fmt.Errorf("CancelarPrecoAgendado: %w", err)
+	}
+	if preco, err := repo.PrecoVigente(ctx, "p-1", instante(30)); err != nil || preco == nil || preco.Preco != 30 {
+		return fmt.Errorf("preço cancelado ainda está vigente: %+v, %v", preco, err)
+	}
+
+	return nil
+}
+
+func listarProdutos(ctx context.Context, repo ports.ProdutoRepository, filtro domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error) {
+	if err := filtro.Validar(); err != nil {
+		return nil, err
+	}
+	pagina, err := repo.Listar(ctx, filtro)
+	if err != nil {
+		return nil, fmt.Errorf("Listar: %w", err)
+	}
+	return pagina, nil
+}
+
+func ptr[T any](valor T) *T {
+	return &valor
+}
+
+// VerificarRemocaoProdutoEmPedido confere que Deletar recusa produtos em
+// pedidos ainda não finalizados. Os dois repositórios devem compartilhar os
+// dados, como no adaptador MySQL; podem já ter registros de outras
+// verificações.
+func VerificarRemocaoProdutoEmPedido(ctx context.Context, produtos ports.ProdutoRepository, pedidos ports.PedidoRepository) error {
+	produto := &domain.Produto{ID: "p-em-pedido", Nome: "Milk-shake", Descricao: "Chocolate 400ml", Preco: 15, Categoria: domain.Categoria("SOBREMESA"), Disponivel: true, CreatedAt: instante(10), UpdatedAt: instante(10), Versao: 1}
+	if err := produtos.Criar(ctx, produto); err != nil {
+		return fmt.Errorf("Criar %s: %w", produto.ID, err)
+	}
+	pedido := &domain.Pedido{ID: "o-com-produto", Status: domain.StatusEmPreparacao, CreatedAt: instante(10), UpdatedAt: instante(10), Versao: 1, Itens: []domain.ItemPedido{
+		{ProdutoID: produto.ID, Nome: produto.Nome, Preco: produto.Preco, Quantidade: 1},
+	}}
+	pedido.CalcularValorTotal()
+	if err := pedidos.Criar(ctx, pedido); err != nil {
+		return fmt.Errorf("Criar %s: %w", pedido.ID, err)
+	}
+
+	if err := esperarErro(produtos.Deletar(ctx, produto.ID), domain.ErrProdutoEmPedidoAberto, "Deletar produto em pedido aberto"); err != nil {
+		return err
+	}
+	if obtido, err := produtos.BuscarPorID(ctx, produto.ID); err != nil || obtido == nil || obtido.Versao != 1 {
+		return fmt.Errorf("Deletar recusado não deve alterar o produto: %+v, %v", obtido, err)
+	}
+
+	pedido.AtualizarStatus(domain.StatusFinalizado)
+	if err := pedidos.Atualizar(ctx, pedido); err != nil {
+		return fmt.Errorf("Atualizar para finalizado: %w", err)
+	}
+	if err := produtos.Deletar(ctx, produto.ID); err != nil {
+		return fmt.Errorf("Deletar produto só em pedido finalizado: %w", err)
+	}
+	if err := esperarErro(produtos.Deletar(ctx, produto.ID), domain.ErrProdutoNaoEncontrado, "Deletar já removido"); err != nil {
+		return err
+	}
+	return nil
+}
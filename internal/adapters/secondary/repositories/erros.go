@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"soat-fiap/internal/core/domain"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const mysqlErroChaveDuplicada = 1062
+
+// traduzirErro converte erros do driver MySQL nas categorias de erro do domínio.
+// Erros desconhecidos são devolvidos sem alteração.
+func traduzirErro(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErroChaveDuplicada {
+		return &domain.Erro{
+			Tipo:     domain.ErrConflito,
+			Codigo:   "REGISTRO_DUPLICADO",
+			Mensagem: "registro duplicado",
+			Causa:    err,
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr) {
+		return domain.NovoErroIndisponivel(err)
+	}
+
+	return err
+}
+
+// executor é atendido por *sql.DB e *sql.Tx, para que a mesma consulta rode
+// dentro ou fora de uma transação.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// tabelasComRemocaoLogica são as tabelas em que um registro com deleted_at
+// preenchido conta como inexistente.
+var tabelasComRemocaoLogica = map[string]bool{"clientes": true, "produtos": true}
+
+// versaoOuNaoEncontrado explica um UPDATE ... WHERE versao = ? que não afetou
+// nenhuma linha: o registro foi removido (inclusive logicamente) ou outra
+// requisição o alterou antes.
+func versaoOuNaoEncontrado(ctx context.Context, db executor, tabela, id string, naoEncontrado error) error {
+	consulta := "SELECT 1 FROM " + tabela + " WHERE id = ?"
+	if tabelasComRemocaoLogica[tabela] {
+		consulta += " AND deleted_at IS NULL"
+	}
+
+	var existe int
+	err := db.QueryRowContext(ctx, consulta, id).Scan(&existe)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return naoEncontrado
+	case err != nil:
+		return traduzirErro(err)
+	default:
+		return domain.ErrVersaoDesatualizada
+	}
+}
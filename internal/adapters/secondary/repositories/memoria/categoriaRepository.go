@@ -0,0 +1,146 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"soat-fiap/internal/core/domain"
+	"sync"
+	"time"
+)
+
+// CategoriaRepository guarda as categorias em memória com a mesma semântica
+// do adaptador MySQL. Começa com as categorias criadas pela migração, e
+// EmUso consulta os produtos do ProdutoRepository informado.
+type CategoriaRepository struct {
+	mu         sync.RWMutex
+	categorias map[domain.Categoria]domain.CategoriaProduto
+	produtos   *ProdutoRepository
+}
+
+func NovoCategoriaRepository(produtos *ProdutoRepository) *CategoriaRepository {
+	r := &CategoriaRepository{
+		categorias: make(map[domain.Categoria]domain.CategoriaProduto),
+		produtos:   produtos,
+	}
+
+	agora := time.Now().UTC()
+	padrao := []struct {
+		codigo       domain.Categoria
+		nome, ingles string
+	}{
+		{"LANCHE", "Lanches", "Burgers"},
+		{"ACOMPANHAMENTO", "Acompanhamentos", "Sides"},
+		{"BEBIDA", "Bebidas", "Drinks"},
+		{"SOBREMESA", "Sobremesas", "Desserts"},
+	}
+	for i, p := range padrao {
+		r.categorias[p.codigo] = domain.CategoriaProduto{
+			Codigo:    p.codigo,
+			Nome:      p.nome,
+			Ordem:     i + 1,
+			Ativa:     true,
+			Traducoes: map[string]string{"en": p.ingles},
+			CreatedAt: agora,
+			UpdatedAt: agora,
+			Versao:    1,
+		}
+	}
+	return r
+}
+
+func (r *CategoriaRepository) Criar(ctx context.Context, categoria *domain.CategoriaProduto) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, existe := r.categorias[categoria.Codigo]; existe {
+		return domain.ErrCategoriaDuplicada
+	}
+
+	r.categorias[categoria.Codigo] = copiarCategoria(*categoria)
+	return nil
+}
+
+func (r *CategoriaRepository) BuscarPorCodigo(ctx context.Context, codigo domain.Categoria) (*domain.CategoriaProduto, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categoria, ok := r.categorias[codigo]
+	if !ok {
+		return nil, nil
+	}
+	copia := copiarCategoria(categoria)
+	return &copia, nil
+}
+
+func (r *CategoriaRepository) Listar(ctx context.Context, filtro domain.FiltroCategorias) ([]*domain.CategoriaProduto, error) {
+	r.mu.RLock()
+	var categorias []*domain.CategoriaProduto
+	for _, categoria := range r.categorias {
+		if filtro.Ativa != nil && categoria.Ativa != *filtro.Ativa {
+			continue
+		}
+		copia := copiarCategoria(categoria)
+		categorias = append(categorias, &copia)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(categorias, func(a, b *domain.CategoriaProduto) int {
+		return cmp.Or(cmp.Compare(a.Ordem, b.Ordem), cmp.Compare(a.Codigo, b.Codigo))
+	})
+	return categorias, nil
+}
+
+func (r *CategoriaRepository) Atualizar(ctx context.Context, categoria *domain.CategoriaProduto) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existente, ok := r.categorias[categoria.Codigo]
+	if !ok {
+		return domain.ErrCategoriaNaoEncontrada
+	}
+	if existente.Versao != categoria.Versao {
+		return domain.ErrVersaoDesatualizada
+	}
+
+	atualizada := copiarCategoria(*categoria)
+	atualizada.CreatedAt = existente.CreatedAt
+	atualizada.Versao++
+	r.categorias[categoria.Codigo] = atualizada
+	categoria.Versao = atualizada.Versao
+	return nil
+}
+
+func (r *CategoriaRepository) Deletar(ctx context.Context, codigo domain.Categoria) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categorias[codigo]; !ok {
+		return domain.ErrCategoriaNaoEncontrada
+	}
+	delete(r.categorias, codigo)
+	return nil
+}
+
+func (r *CategoriaRepository) EmUso(ctx context.Context, codigo domain.Categoria) (bool, error) {
+	r.produtos.mu.RLock()
+	defer r.produtos.mu.RUnlock()
+
+	for _, produto := range r.produtos.produtos {
+		if produto.Categoria == codigo {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// copiarCategoria também copia as traduções, para que o mapa guardado não
+// seja compartilhado com o chamador.
+func copiarCategoria(categoria domain.CategoriaProduto) domain.CategoriaProduto {
+	categoria.Traducoes = maps.Clone(categoria.Traducoes)
+	if categoria.Traducoes == nil {
+		categoria.Traducoes = map[string]string{}
+	}
+	return categoria
+}
@@ -0,0 +1,150 @@
+package memoria
+
+import (
+	"context"
+	"soat-fiap/internal/core/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ClienteRepository guarda os clientes em memória. Segue a mesma semântica do
+// adaptador MySQL: nil quando não encontra, CPF único, versão conferida nas
+// atualizações e cópias nas leituras e escritas para que o chamador não
+// altere o estado armazenado.
+type ClienteRepository struct {
+	mu       sync.RWMutex
+	clientes map[string]domain.Cliente
+}
+
+func NovoClienteRepository() *ClienteRepository {
+	return &ClienteRepository{
+		clientes: make(map[string]domain.Cliente),
+	}
+}
+
+func (r *ClienteRepository) Criar(ctx context.Context, cliente *domain.Cliente) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, existe := r.clientes[cliente.ID]; existe {
+		return domain.NovoErroConflito("REGISTRO_DUPLICADO", "registro duplicado")
+	}
+	if r.cpfEmUso(cliente.CPF, cliente.ID) {
+		return domain.ErrCPFDuplicado
+	}
+
+	r.clientes[cliente.ID] = *cliente
+	return nil
+}
+
+func (r *ClienteRepository) BuscarPorID(ctx context.Context, id string) (*domain.Cliente, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cliente, ok := r.clientes[id]
+	if !ok || cliente.DeletedAt != nil {
+		return nil, nil
+	}
+	return &cliente, nil
+}
+
+func (r *ClienteRepository) BuscarPorCPF(ctx context.Context, cpf string) (*domain.Cliente, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, cliente := range r.clientes {
+		if cliente.CPF == cpf && cliente.DeletedAt == nil {
+			return &cliente, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *ClienteRepository) Listar(ctx context.Context, filtro domain.FiltroClientes) (*domain.Pagina[*domain.Cliente], error) {
+	r.mu.RLock()
+	var clientes []*domain.Cliente
+	for _, cliente := range r.clientes {
+		if (cliente.DeletedAt != nil) != filtro.Removidos {
+			continue
+		}
+		if filtro.Nome != "" && !strings.Contains(strings.ToLower(cliente.Nome), strings.ToLower(filtro.Nome)) {
+			continue
+		}
+		copia := cliente
+		clientes = append(clientes, &copia)
+	}
+	r.mu.RUnlock()
+
+	return paginar(clientes, filtro.Paginacao, valorOrdenacaoCliente, func(c *domain.Cliente) string { return c.ID }, func(c *domain.Cliente) string {
+		return c.ValorOrdenacao(filtro.Ordenacao)
+	})
+}
+
+func (r *ClienteRepository) Atualizar(ctx context.Context, cliente *domain.Cliente) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existente, ok := r.clientes[cliente.ID]
+	if !ok || existente.DeletedAt != nil {
+		return domain.ErrClienteNaoEncontrado
+	}
+	if existente.Versao != cliente.Versao {
+		return domain.ErrVersaoDesatualizada
+	}
+	if r.cpfEmUso(cliente.CPF, cliente.ID) {
+		return domain.ErrCPFDuplicado
+	}
+
+	atualizado := *cliente
+	atualizado.CreatedAt = existente.CreatedAt
+	atualizado.Versao++
+	r.clientes[cliente.ID] = atualizado
+	cliente.Versao = atualizado.Versao
+	return nil
+}
+
+func (r *ClienteRepository) Deletar(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cliente, ok := r.clientes[id]
+	if !ok || cliente.DeletedAt != nil {
+		return domain.ErrClienteNaoEncontrado
+	}
+	agora := time.Now().UTC()
+	cliente.DeletedAt = &agora
+	cliente.Versao++
+	r.clientes[id] = cliente
+	return nil
+}
+
+func (r *ClienteRepository) Restaurar(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cliente, ok := r.clientes[id]
+	if !ok || cliente.DeletedAt == nil {
+		return domain.ErrClienteNaoEncontrado
+	}
+	cliente.DeletedAt = nil
+	cliente.Versao++
+	r.clientes[id] = cliente
+	return nil
+}
+
+func (r *ClienteRepository) cpfEmUso(cpf, exceto string) bool {
+	for id, cliente := range r.clientes {
+		if cliente.CPF == cpf && id != exceto {
+			return true
+		}
+	}
+	return false
+}
+
+func valorOrdenacaoCliente(c *domain.Cliente, campo string) any {
+	if campo == domain.OrdenarClientesPorCriacao {
+		return c.CreatedAt
+	}
+	return c.Nome
+}
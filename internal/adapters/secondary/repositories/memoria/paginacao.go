@@ -0,0 +1,90 @@
+package memoria
+
+import (
+	"cmp"
+	"slices"
+	"soat-fiap/internal/core/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// paginar ordena os itens já filtrados pelo campo da paginação e pelo ID,
+// descarta o que vem antes do cursor e monta a página, reproduzindo o keyset
+// dos adaptadores SQL. valor devolve string, float64 ou time.Time.
+func paginar[T any](itens []T, p domain.Paginacao, valor func(T, string) any, id func(T) string, cursorDe func(T) string) (*domain.Pagina[T], error) {
+	comparar := func(a, b T) int {
+		c := compararValores(valor(a, p.Ordenacao), valor(b, p.Ordenacao))
+		if c == 0 {
+			c = strings.Compare(id(a), id(b))
+		}
+		if p.Decrescente {
+			return -c
+		}
+		return c
+	}
+	slices.SortFunc(itens, comparar)
+
+	cursor, err := p.CursorAtual()
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil && len(itens) > 0 {
+		referencia, err := converterCursor(valor(itens[0], p.Ordenacao), cursor.Valor)
+		if err != nil {
+			return nil, err
+		}
+		inicio := len(itens)
+		for i, item := range itens {
+			c := compararValores(valor(item, p.Ordenacao), referencia)
+			if c == 0 {
+				c = strings.Compare(id(item), cursor.ID)
+			}
+			if p.Decrescente {
+				c = -c
+			}
+			if c > 0 {
+				inicio = i
+				break
+			}
+		}
+		itens = itens[inicio:]
+	}
+
+	if len(itens) > p.Limite+1 {
+		itens = itens[:p.Limite+1]
+	}
+
+	return domain.NovaPagina(itens, p, func(item T) (string, string) {
+		return cursorDe(item), id(item)
+	}), nil
+}
+
+func compararValores(a, b any) int {
+	switch va := a.(type) {
+	case string:
+		return strings.Compare(strings.ToLower(va), strings.ToLower(b.(string)))
+	case float64:
+		return cmp.Compare(va, b.(float64))
+	case time.Time:
+		return va.Compare(b.(time.Time))
+	default:
+		return 0
+	}
+}
+
+// converterCursor interpreta o valor textual do cursor com o tipo do campo.
+func converterCursor(modelo any, valor string) (any, error) {
+	var convertido any = valor
+	var err error
+	switch modelo.(type) {
+	case float64:
+		convertido, err = strconv.ParseFloat(valor, 64)
+	case time.Time:
+		convertido, err = time.Parse(time.RFC3339Nano, valor)
+	}
+	if err != nil {
+		return nil, domain.NovoErroValidacao("cursor", "INVALIDO", "cursor inválido")
+	}
+	return convertido, nil
+}
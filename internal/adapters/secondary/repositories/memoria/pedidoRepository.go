@@ -0,0 +1,148 @@
+package memoria
+
+import (
+	"context"
+	"slices"
+	"soat-fiap/internal/core/domain"
+	"strings"
+	"sync"
+)
+
+// PedidoRepository guarda os pedidos em memória com a mesma semântica do
+// adaptador MySQL: Atualizar altera apenas status, data de atualização e versão e os
+// itens são devolvidos ordenados por produto.
+type PedidoRepository struct {
+	mu      sync.RWMutex
+	pedidos map[string]domain.Pedido
+}
+
+func NovoPedidoRepository() *PedidoRepository {
+	return &PedidoRepository{
+		pedidos: make(map[string]domain.Pedido),
+	}
+}
+
+func (r *PedidoRepository) Criar(ctx context.Context, pedido *domain.Pedido) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, existe := r.pedidos[pedido.ID]; existe {
+		return domain.NovoErroConflito("REGISTRO_DUPLICADO", "registro duplicado")
+	}
+
+	r.pedidos[pedido.ID] = copiarPedido(pedido)
+	return nil
+}
+
+func (r *PedidoRepository) BuscarPorID(ctx context.Context, id string) (*domain.Pedido, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pedido, ok := r.pedidos[id]
+	if !ok {
+		return nil, nil
+	}
+	copia := copiarPedido(&pedido)
+	return &copia, nil
+}
+
+func (r *PedidoRepository) Listar(ctx context.Context, filtro domain.FiltroPedidos) (*domain.Pagina[*domain.Pedido], error) {
+	r.mu.RLock()
+	var pedidos []*domain.Pedido
+	for _, pedido := range r.pedidos {
+		if len(filtro.Status) > 0 && !slices.Contains(filtro.Status, pedido.Status) {
+			continue
+		}
+		if filtro.ClienteID != "" && (pedido.ClienteID == nil || *pedido.ClienteID != filtro.ClienteID) {
+			continue
+		}
+		if filtro.De != nil && pedido.CreatedAt.Before(*filtro.De) {
+			continue
+		}
+		if filtro.Ate != nil && !pedido.CreatedAt.Before(*filtro.Ate) {
+			continue
+		}
+		copia := copiarPedido(&pedido)
+		pedidos = append(pedidos, &copia)
+	}
+	r.mu.RUnlock()
+
+	return paginar(pedidos, filtro.Paginacao, valorOrdenacaoPedido, func(p *domain.Pedido) string { return p.ID }, func(p *domain.Pedido) string {
+		return p.ValorOrdenacao(filtro.Ordenacao)
+	})
+}
+
+func (r *PedidoRepository) Atualizar(ctx context.Context, pedido *domain.Pedido) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existente, ok := r.pedidos[pedido.ID]
+	if !ok {
+		return domain.ErrPedidoNaoEncontrado
+	}
+	if existente.Versao != pedido.Versao {
+		return domain.ErrVersaoDesatualizada
+	}
+
+	existente.Status = pedido.Status
+	existente.UpdatedAt = pedido.UpdatedAt
+	existente.Versao++
+	r.pedidos[pedido.ID] = existente
+	pedido.Versao = existente.Versao
+	return nil
+}
+
+// existeAbertoComProduto informa se algum pedido ainda não finalizado tem
+// item do produto. É chamado por ProdutoRepository.Deletar com o lock dos
+// produtos adquirido; a ordem é sempre produtos e depois pedidos.
+func (r *PedidoRepository) existeAbertoComProduto(produtoID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, pedido := range r.pedidos {
+		if pedido.Status == domain.StatusFinalizado {
+			continue
+		}
+		for _, item := range pedido.Itens {
+			if item.ProdutoID == produtoID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *PedidoRepository) ContarPorStatus(ctx context.Context) (map[domain.StatusPedido]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contagem := make(map[domain.StatusPedido]int)
+	for _, pedido := range r.pedidos {
+		contagem[pedido.Status]++
+	}
+	return contagem, nil
+}
+
+func copiarPedido(pedido *domain.Pedido) domain.Pedido {
+	copia := *pedido
+	if pedido.ClienteID != nil {
+		clienteID := *pedido.ClienteID
+		copia.ClienteID = &clienteID
+	}
+	copia.Itens = slices.Clone(pedido.Itens)
+	slices.SortStableFunc(copia.Itens, func(a, b domain.ItemPedido) int {
+		return strings.Compare(a.ProdutoID, b.ProdutoID)
+	})
+	return copia
+}
+
+func valorOrdenacaoPedido(p *domain.Pedido, campo string) any {
+	switch campo {
+	case domain.OrdenarPedidosPorAtualizacao:
+		return p.UpdatedAt
+	case domain.OrdenarPedidosPorValor:
+		return p.ValorTotal
+	default:
+		return p.CreatedAt
+	}
+}
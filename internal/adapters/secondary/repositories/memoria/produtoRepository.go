@@ -0,0 +1,265 @@
+package memoria
+
+import (
+	"context"
+	"slices"
+	"soat-fiap/internal/core/domain"
+	"sync"
+	"time"
+)
+
+// ProdutoRepository guarda os produtos em memória com a mesma semântica do
+// adaptador MySQL. Deletar consulta os pedidos do PedidoRepository informado.
+type ProdutoRepository struct {
+	mu       sync.RWMutex
+	produtos map[string]domain.Produto
+	pedidos  *PedidoRepository
+	// precos é o histórico de preços em ordem de registro; ultimoPreco faz o
+	// papel da coluna autoincremento.
+	precos      []domain.PrecoProduto
+	ultimoPreco int64
+}
+
+func NovoProdutoRepository(pedidos *PedidoRepository) *ProdutoRepository {
+	return &ProdutoRepository{
+		produtos: make(map[string]domain.Produto),
+		pedidos:  pedidos,
+	}
+}
+
+func (r *ProdutoRepository) Criar(ctx context.Context, produto *domain.Produto) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, existe := r.produtos[produto.ID]; existe {
+		return domain.NovoErroConflito("REGISTRO_DUPLICADO", "registro duplicado")
+	}
+
+	r.produtos[produto.ID] = *produto
+	r.registrarPreco(&domain.PrecoProduto{ProdutoID: produto.ID, Preco: produto.Preco, VigenteDesde: produto.CreatedAt, CreatedAt: produto.CreatedAt})
+	return nil
+}
+
+func (r *ProdutoRepository) BuscarPorID(ctx context.Context, id string) (*domain.Produto, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	produto, ok := r.produtos[id]
+	if !ok || produto.DeletedAt != nil {
+		return nil, nil
+	}
+	return &produto, nil
+}
+
+func (r *ProdutoRepository) Listar(ctx context.Context, filtro domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error) {
+	r.mu.RLock()
+	var produtos []*domain.Produto
+	for _, produto := range r.produtos {
+		if (produto.DeletedAt != nil) != filtro.Removidos {
+			continue
+		}
+		if filtro.Categoria != "" && produto.Categoria != filtro.Categoria {
+			continue
+		}
+		if filtro.PrecoMin != nil && produto.Preco < *filtro.PrecoMin {
+			continue
+		}
+		if filtro.PrecoMax != nil && produto.Preco > *filtro.PrecoMax {
+			continue
+		}
+		if filtro.Disponivel != nil && produto.Disponivel != *filtro.Disponivel {
+			continue
+		}
+		copia := produto
+		produtos = append(produtos, &copia)
+	}
+	r.mu.RUnlock()
+
+	return paginar(produtos, filtro.Paginacao, valorOrdenacaoProduto, func(p *domain.Produto) string { return p.ID }, func(p *domain.Produto) string {
+		return p.ValorOrdenacao(filtro.Ordenacao)
+	})
+}
+
+func (r *ProdutoRepository) Atualizar(ctx context.Context, produto *domain.Produto) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existente, ok := r.produtos[produto.ID]
+	if !ok || existente.DeletedAt != nil {
+		return domain.ErrProdutoNaoEncontrado
+	}
+	if existente.Versao != produto.Versao {
+		return domain.ErrVersaoDesatualizada
+	}
+
+	atualizado := *produto
+	atualizado.CreatedAt = existente.CreatedAt
+	atualizado.Imagem = existente.Imagem
+	atualizado.Versao++
+	r.produtos[produto.ID] = atualizado
+	produto.Versao = atualizado.Versao
+	if produto.Preco != existente.Preco {
+		r.registrarPreco(&domain.PrecoProduto{ProdutoID: produto.ID, Preco: produto.Preco, VigenteDesde: produto.UpdatedAt, CreatedAt: produto.UpdatedAt})
+	}
+	return nil
+}
+
+func (r *ProdutoRepository) AtualizarImagem(ctx context.Context, produto *domain.Produto) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existente, ok := r.produtos[produto.ID]
+	if !ok || existente.DeletedAt != nil {
+		return domain.ErrProdutoNaoEncontrado
+	}
+	if existente.Versao != produto.Versao {
+		return domain.ErrVersaoDesatualizada
+	}
+
+	var imagem *domain.Imagem
+	if produto.Imagem != nil {
+		imagem = &domain.Imagem{Chave: produto.Imagem.Chave}
+	}
+	existente.Imagem = imagem
+	existente.UpdatedAt = produto.UpdatedAt
+	existente.Versao++
+	r.produtos[produto.ID] = existente
+	produto.Versao = existente.Versao
+	return nil
+}
+
+func (r *ProdutoRepository) Deletar(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	produto, ok := r.produtos[id]
+	if !ok || produto.DeletedAt != nil {
+		return domain.ErrProdutoNaoEncontrado
+	}
+	if r.pedidos.existeAbertoComProduto(id) {
+		return domain.ErrProdutoEmPedidoAberto
+	}
+	agora := time.Now().UTC()
+	produto.DeletedAt = &agora
+	produto.Versao++
+	r.produtos[id] = produto
+	return nil
+}
+
+func (r *ProdutoRepository) Restaurar(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	produto, ok := r.produtos[id]
+	if !ok || produto.DeletedAt == nil {
+		return domain.ErrProdutoNaoEncontrado
+	}
+	produto.DeletedAt = nil
+	produto.Versao++
+	r.produtos[id] = produto
+	return nil
+}
+
+func (r *ProdutoRepository) ListarPrecos(ctx context.Context, produtoID string) ([]*domain.PrecoProduto, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	precos := []*domain.PrecoProduto{}
+	for i := len(r.precos) - 1; i >= 0; i-- {
+		if r.precos[i].ProdutoID == produtoID {
+			copia := r.precos[i]
+			precos = append(precos, &copia)
+		}
+	}
+	slices.SortStableFunc(precos, func(a, b *domain.PrecoProduto) int {
+		return b.VigenteDesde.Compare(a.VigenteDesde)
+	})
+	return precos, nil
+}
+
+func (r *ProdutoRepository) PrecoVigente(ctx context.Context, produtoID string, em time.Time) (*domain.PrecoProduto, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if preco := r.precoVigente(produtoID, em); preco != nil {
+		copia := *preco
+		return &copia, nil
+	}
+	return nil, nil
+}
+
+func (r *ProdutoRepository) AgendarPreco(ctx context.Context, preco *domain.PrecoProduto) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.produtos[preco.ProdutoID]; !ok {
+		return domain.ErrProdutoNaoEncontrado
+	}
+	r.registrarPreco(preco)
+	return nil
+}
+
+func (r *ProdutoRepository) CancelarPrecoAgendado(ctx context.Context, produtoID string, id int64, agora time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, preco := range r.precos {
+		if preco.ID == id && preco.ProdutoID == produtoID && preco.VigenteDesde.After(agora) {
+			r.precos = slices.Delete(r.precos, i, i+1)
+			return nil
+		}
+	}
+	return domain.ErrPrecoAgendadoNaoEncontrado
+}
+
+func (r *ProdutoRepository) AplicarPrecosVigentes(ctx context.Context, em time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var alterados int64
+	for id, produto := range r.produtos {
+		preco := r.precoVigente(id, em)
+		if produto.DeletedAt != nil || preco == nil || preco.Preco == produto.Preco {
+			continue
+		}
+		produto.Preco = preco.Preco
+		produto.UpdatedAt = em
+		produto.Versao++
+		r.produtos[id] = produto
+		alterados++
+	}
+	return alterados, nil
+}
+
+// registrarPreco exige o bloqueio de escrita.
+func (r *ProdutoRepository) registrarPreco(preco *domain.PrecoProduto) {
+	r.ultimoPreco++
+	preco.ID = r.ultimoPreco
+	r.precos = append(r.precos, *preco)
+}
+
+// precoVigente exige ao menos o bloqueio de leitura.
+func (r *ProdutoRepository) precoVigente(produtoID string, em time.Time) *domain.PrecoProduto {
+	var vigente *domain.PrecoProduto
+	for i := range r.precos {
+		preco := &r.precos[i]
+		if preco.ProdutoID != produtoID || preco.VigenteDesde.After(em) {
+			continue
+		}
+		if vigente == nil || !preco.VigenteDesde.Before(vigente.VigenteDesde) {
+			vigente = preco
+		}
+	}
+	return vigente
+}
+
+func valorOrdenacaoProduto(p *domain.Produto, campo string) any {
+	switch campo {
+	case domain.OrdenarProdutosPorPreco:
+		return p.Preco
+	case domain.OrdenarProdutosPorCriacao:
+		return p.CreatedAt
+	default:
+		return p.Nome
+	}
+}
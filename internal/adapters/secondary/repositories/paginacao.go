@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"fmt"
+	"soat-fiap/internal/core/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// colunaOrdenacao liga um campo de ordenação da API a uma coluna da tabela.
+// converter transforma o valor guardado no cursor no parâmetro da consulta.
+type colunaOrdenacao struct {
+	nome      string
+	converter func(string) (any, error)
+}
+
+func cursorTexto(valor string) (any, error) {
+	return valor, nil
+}
+
+func cursorDecimal(valor string) (any, error) {
+	return strconv.ParseFloat(valor, 64)
+}
+
+func cursorTempo(valor string) (any, error) {
+	return time.Parse(time.RFC3339Nano, valor)
+}
+
+// consulta acumula as condições WHERE e os parâmetros de uma listagem.
+type consulta struct {
+	condicoes []string
+	args      []any
+}
+
+func (c *consulta) onde(condicao string, args ...any) {
+	c.condicoes = append(c.condicoes, condicao)
+	c.args = append(c.args, args...)
+}
+
+func (c *consulta) clausulaWhere() string {
+	if len(c.condicoes) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(c.condicoes, " AND ")
+}
+
+// paginar aplica a condição de keyset do cursor e devolve ORDER BY e LIMIT.
+// Deve ser chamado depois de todos os filtros e antes de clausulaWhere, pois
+// acrescenta a condição do cursor e o LIMIT como últimos parâmetros.
+// A consulta busca uma linha a mais para indicar se há próxima página.
+func (c *consulta) paginar(p domain.Paginacao, colunas map[string]colunaOrdenacao) (string, error) {
+	coluna := colunas[p.Ordenacao]
+
+	direcao, comparador := "ASC", ">"
+	if p.Decrescente {
+		direcao, comparador = "DESC", "<"
+	}
+
+	cursor, err := p.CursorAtual()
+	if err != nil {
+		return "", err
+	}
+	if cursor != nil {
+		valor, err := coluna.converter(cursor.Valor)
+		if err != nil {
+			return "", domain.NovoErroValidacao("cursor", "INVALIDO", "cursor inválido")
+		}
+		c.onde(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", coluna.nome, comparador),
+			valor, valor, cursor.ID,
+		)
+	}
+
+	c.args = append(c.args, p.Limite+1)
+	return fmt.Sprintf("ORDER BY %[1]s %[2]s, id %[2]s LIMIT ?", coluna.nome, direcao), nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"soat-fiap/internal/core/domain"
+	"soat-fiap/pkg/logger"
+	"strings"
 	"time"
 )
 
@@ -20,16 +22,16 @@ func NovoPedidoRepository(db *sql.DB) *PedidoRepository {
 func (r *PedidoRepository) Criar(ctx context.Context, pedido *domain.Pedido) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 	defer tx.Rollback()
 
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO pedidos (id, cliente_id, valor_total, status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO pedidos (id, cliente_id, valor_total, status, created_at, updated_at, versao)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 	defer stmt.Close()
 
@@ -40,9 +42,10 @@ func (r *PedidoRepository) Criar(ctx context.Context, pedido *domain.Pedido) err
 		pedido.Status,
 		pedido.CreatedAt.Format(time.RFC3339),
 		pedido.UpdatedAt.Format(time.RFC3339),
+		pedido.Versao,
 	)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	stmtItem, err := tx.PrepareContext(ctx, `
@@ -50,7 +53,7 @@ func (r *PedidoRepository) Criar(ctx context.Context, pedido *domain.Pedido) err
 		VALUES (?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 	defer stmtItem.Close()
 
@@ -64,21 +67,26 @@ func (r *PedidoRepository) Criar(ctx context.Context, pedido *domain.Pedido) err
 			item.Observacao,
 		)
 		if err != nil {
-			return err
+			return traduzirErro(err)
 		}
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return traduzirErro(err)
+	}
+
+	logger.DoContexto(ctx).Debug("pedido gravado", "pedido_id", pedido.ID, "itens", len(pedido.Itens))
+	return nil
 }
 
 func (r *PedidoRepository) BuscarPorID(ctx context.Context, id string) (*domain.Pedido, error) {
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, cliente_id, valor_total, status, created_at, updated_at
+		SELECT id, cliente_id, valor_total, status, created_at, updated_at, versao
 		FROM pedidos
 		WHERE id = ?
 	`)
 	if err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 	defer stmt.Close()
 
@@ -93,13 +101,14 @@ func (r *PedidoRepository) BuscarPorID(ctx context.Context, id string) (*domain.
 		&pedido.Status,
 		&createdAtStr,
 		&updatedAtStr,
+		&pedido.Versao,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 
 	if clienteID.Valid {
@@ -109,68 +118,75 @@ func (r *PedidoRepository) BuscarPorID(ctx context.Context, id string) (*domain.
 	pedido.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
 	pedido.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAtStr)
 
-	itens, err := r.buscarItensPorPedidoID(ctx, id)
-	if err != nil {
+	if err := r.carregarItens(ctx, []*domain.Pedido{&pedido}); err != nil {
 		return nil, err
 	}
 
-	pedido.Itens = itens
-
 	return &pedido, nil
 }
 
-func (r *PedidoRepository) Listar(ctx context.Context) ([]*domain.Pedido, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, cliente_id, valor_total, status, created_at, updated_at
-		FROM pedidos
-		ORDER BY created_at DESC
-	`)
+var colunasOrdenacaoPedidos = map[string]colunaOrdenacao{
+	domain.OrdenarPedidosPorCriacao:     {nome: "created_at", converter: cursorTempo},
+	domain.OrdenarPedidosPorAtualizacao: {nome: "updated_at", converter: cursorTempo},
+	domain.OrdenarPedidosPorValor:       {nome: "valor_total", converter: cursorDecimal},
+}
+
+func (r *PedidoRepository) Listar(ctx context.Context, filtro domain.FiltroPedidos) (*domain.Pagina[*domain.Pedido], error) {
+	var c consulta
+	if len(filtro.Status) > 0 {
+		marcadores := strings.Repeat("?, ", len(filtro.Status)-1) + "?"
+		args := make([]any, len(filtro.Status))
+		for i, status := range filtro.Status {
+			args[i] = status
+		}
+		c.onde("status IN ("+marcadores+")", args...)
+	}
+	if filtro.ClienteID != "" {
+		c.onde("cliente_id = ?", filtro.ClienteID)
+	}
+	if filtro.De != nil {
+		c.onde("created_at >= ?", *filtro.De)
+	}
+	if filtro.Ate != nil {
+		c.onde("created_at < ?", *filtro.Ate)
+	}
+	ordenacao, err := c.paginar(filtro.Paginacao, colunasOrdenacaoPedidos)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	return r.processarResultados(ctx, rows)
-}
-
-func (r *PedidoRepository) ListarPorStatus(ctx context.Context, status domain.StatusPedido) ([]*domain.Pedido, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, cliente_id, valor_total, status, created_at, updated_at
+		SELECT id, cliente_id, valor_total, status, created_at, updated_at, versao
 		FROM pedidos
-		WHERE status = ?
-		ORDER BY created_at ASC
-	`, status)
+		`+c.clausulaWhere()+`
+		`+ordenacao, c.args...)
 	if err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 	defer rows.Close()
 
-	return r.processarResultados(ctx, rows)
-}
-
-func (r *PedidoRepository) ListarPorCliente(ctx context.Context, clienteID string) ([]*domain.Pedido, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, cliente_id, valor_total, status, created_at, updated_at
-		FROM pedidos
-		WHERE cliente_id = ?
-		ORDER BY created_at DESC
-	`, clienteID)
+	pedidos, err := r.processarResultados(rows)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	return r.processarResultados(ctx, rows)
+	if err := r.carregarItens(ctx, pedidos); err != nil {
+		return nil, err
+	}
+
+	return domain.NovaPagina(pedidos, filtro.Paginacao, func(p *domain.Pedido) (string, string) {
+		return p.ValorOrdenacao(filtro.Ordenacao), p.ID
+	}), nil
 }
 
 func (r *PedidoRepository) Atualizar(ctx context.Context, pedido *domain.Pedido) error {
 	stmt, err := r.db.PrepareContext(ctx, `
 		UPDATE pedidos
-		SET status = ?, updated_at = ?
-		WHERE id = ?
+		SET status = ?, updated_at = ?, versao = versao + 1
+		WHERE id = ? AND versao = ?
 	`)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 	defer stmt.Close()
 
@@ -178,24 +194,29 @@ func (r *PedidoRepository) Atualizar(ctx context.Context, pedido *domain.Pedido)
 		pedido.Status,
 		pedido.UpdatedAt.Format(time.RFC3339),
 		pedido.ID,
+		pedido.Versao,
 	)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return versaoOuNaoEncontrado(ctx, r.db, "pedidos", pedido.ID, domain.ErrPedidoNaoEncontrado)
 	}
 
+	pedido.Versao++
 	return nil
 }
 
-func (r *PedidoRepository) processarResultados(ctx context.Context, rows *sql.Rows) ([]*domain.Pedido, error) {
+// processarResultados lê todas as linhas de pedidos. Os itens são carregados
+// depois, em lote, por carregarItens, para não abrir uma consulta por pedido
+// enquanto o cursor externo ainda segura a conexão.
+func (r *PedidoRepository) processarResultados(rows *sql.Rows) ([]*domain.Pedido, error) {
 	var pedidos []*domain.Pedido
 
 	for rows.Next() {
@@ -210,9 +231,10 @@ func (r *PedidoRepository) processarResultados(ctx context.Context, rows *sql.Ro
 			&pedido.Status,
 			&createdAtStr,
 			&updatedAtStr,
+			&pedido.Versao,
 		)
 		if err != nil {
-			return nil, err
+			return nil, traduzirErro(err)
 		}
 
 		if clienteID.Valid {
@@ -222,41 +244,48 @@ func (r *PedidoRepository) processarResultados(ctx context.Context, rows *sql.Ro
 		pedido.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
 		pedido.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAtStr)
 
-		itens, err := r.buscarItensPorPedidoID(ctx, pedido.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		pedido.Itens = itens
 		pedidos = append(pedidos, &pedido)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 
 	return pedidos, nil
 }
 
-func (r *PedidoRepository) buscarItensPorPedidoID(ctx context.Context, pedidoID string) ([]domain.ItemPedido, error) {
+// carregarItens busca os itens de todos os pedidos em uma única consulta.
+func (r *PedidoRepository) carregarItens(ctx context.Context, pedidos []*domain.Pedido) error {
+	if len(pedidos) == 0 {
+		return nil
+	}
+
+	porID := make(map[string]*domain.Pedido, len(pedidos))
+	args := make([]any, len(pedidos))
+	for i, pedido := range pedidos {
+		porID[pedido.ID] = pedido
+		args[i] = pedido.ID
+	}
+	marcadores := strings.Repeat("?, ", len(pedidos)-1) + "?"
+
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT produto_id, nome, preco, quantidade, observacao
+		SELECT pedido_id, produto_id, nome, preco, quantidade, observacao
 		FROM pedido_itens
-		WHERE pedido_id = ?
-		ORDER BY produto_id
-	`, pedidoID)
+		WHERE pedido_id IN (`+marcadores+`)
+		ORDER BY pedido_id, produto_id
+	`, args...)
 	if err != nil {
-		return nil, err
+		return traduzirErro(err)
 	}
 	defer rows.Close()
 
-	var itens []domain.ItemPedido
-
 	for rows.Next() {
+		var pedidoID string
 		var item domain.ItemPedido
 		var observacao sql.NullString
 
 		err := rows.Scan(
+			&pedidoID,
 			&item.ProdutoID,
 			&item.Nome,
 			&item.Preco,
@@ -264,19 +293,48 @@ func (r *PedidoRepository) buscarItensPorPedidoID(ctx context.Context, pedidoID
 			&observacao,
 		)
 		if err != nil {
-			return nil, err
+			return traduzirErro(err)
 		}
 
 		if observacao.Valid {
 			item.Observacao = observacao.String
 		}
 
-		itens = append(itens, item)
+		if pedido, ok := porID[pedidoID]; ok {
+			pedido.Itens = append(pedido.Itens, item)
+		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return traduzirErro(err)
 	}
 
-	return itens, nil
+	logger.DoContexto(ctx).Debug("itens carregados em lote", "pedidos", len(pedidos))
+	return nil
+}
+
+func (r *PedidoRepository) ContarPorStatus(ctx context.Context) (map[domain.StatusPedido]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT status, COUNT(*)
+		FROM pedidos
+		GROUP BY status
+	`)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	contagem := make(map[domain.StatusPedido]int)
+	for rows.Next() {
+		var status domain.StatusPedido
+		var total int
+		if err := rows.Scan(&status, &total); err != nil {
+			return nil, traduzirErro(err)
+		}
+		contagem[status] = total
+	}
+	if err := rows.Err(); err != nil {
+		return nil, traduzirErro(err)
+	}
+	return contagem, nil
 }
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"soat-fiap/internal/core/domain"
+)
+
+// bancoFalso é um driver database/sql que responde às consultas de listagem
+// de pedidos com dados gerados e conta quantas consultas recebeu. Substitui o
+// MySQL para medir o número de idas ao banco de PedidoRepository.Listar.
+type bancoFalso struct {
+	pedidos        int
+	itensPorPedido int
+	consultas      atomic.Int64
+}
+
+func (b *bancoFalso) Connect(context.Context) (driver.Conn, error) { return conexaoFalsa{b}, nil }
+func (b *bancoFalso) Driver() driver.Driver                        { return b }
+func (b *bancoFalso) Open(string) (driver.Conn, error)             { return conexaoFalsa{b}, nil }
+
+type conexaoFalsa struct {
+	banco *bancoFalso
+}
+
+func (c conexaoFalsa) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("bancoFalso: Prepare não suportado")
+}
+func (c conexaoFalsa) Close() error { return nil }
+func (c conexaoFalsa) Begin() (driver.Tx, error) {
+	return nil, errors.New("bancoFalso: transações não suportadas")
+}
+
+func (c conexaoFalsa) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.banco.consultas.Add(1)
+
+	switch {
+	case strings.Contains(query, "FROM pedido_itens"):
+		rows := &linhasFalsas{colunas: []string{"pedido_id", "produto_id", "nome", "preco", "quantidade", "observacao"}}
+		for _, arg := range args {
+			for i := range c.banco.itensPorPedido {
+				rows.linhas = append(rows.linhas, []driver.Value{arg.Value, fmt.Sprintf("produto-%d", i), "X-Burger", 29.9, int64(1), nil})
+			}
+		}
+		return rows, nil
+	case strings.Contains(query, "FROM pedidos"):
+		rows := &linhasFalsas{colunas: []string{"id", "cliente_id", "valor_total", "status", "created_at", "updated_at", "versao"}}
+		for i := range c.banco.pedidos {
+			rows.linhas = append(rows.linhas, []driver.Value{fmt.Sprintf("pedido-%04d", i), nil, 89.7, string(domain.StatusRecebido), "2024-05-01T12:00:00Z", "2024-05-01T12:00:00Z", int64(1)})
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("bancoFalso: consulta inesperada: %s", query)
+}
+
+type linhasFalsas struct {
+	colunas []string
+	linhas  [][]driver.Value
+	atual   int
+}
+
+func (r *linhasFalsas) Columns() []string { return r.colunas }
+func (r *linhasFalsas) Close() error      { return nil }
+
+func (r *linhasFalsas) Next(dest []driver.Value) error {
+	if r.atual == len(r.linhas) {
+		return io.EOF
+	}
+	copy(dest, r.linhas[r.atual])
+	r.atual++
+	return nil
+}
+
+func listarPedidos(b *bancoFalso) (*domain.Pagina[*domain.Pedido], error) {
+	db := sql.OpenDB(b)
+	defer db.Close()
+
+	return NovoPedidoRepository(db).Listar(context.Background(), domain.FiltroPedidos{
+		Paginacao: domain.Paginacao{Limite: b.pedidos, Ordenacao: domain.OrdenarPedidosPorCriacao},
+	})
+}
+
+// Listar deve buscar os pedidos e depois os itens de todos eles em uma
+// única consulta, qualquer que seja o tamanho da página (sem N+1).
+func TestListarPedidosCarregaItensEmLote(t *testing.T) {
+	for _, n := range []int{1, 10, 100} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			banco := &bancoFalso{pedidos: n, itensPorPedido: 3}
+
+			pagina, err := listarPedidos(banco)
+			if err != nil {
+				t.Fatalf("Listar: %v", err)
+			}
+
+			if len(pagina.Itens) != n {
+				t.Fatalf("esperados %d pedidos, obtidos %d", n, len(pagina.Itens))
+			}
+			for _, pedido := range pagina.Itens {
+				if len(pedido.Itens) != 3 {
+					t.Fatalf("pedido %s: esperados 3 itens, obtidos %d", pedido.ID, len(pedido.Itens))
+				}
+			}
+			if consultas := banco.consultas.Load(); consultas != 2 {
+				t.Fatalf("esperadas 2 consultas, obtidas %d", consultas)
+			}
+		})
+	}
+}
+
+func BenchmarkListar(b *testing.B) {
+	banco := &bancoFalso{pedidos: 100, itensPorPedido: 3}
+	db := sql.OpenDB(banco)
+	defer db.Close()
+	repo := NovoPedidoRepository(db)
+	filtro := domain.FiltroPedidos{
+		Paginacao: domain.Paginacao{Limite: banco.pedidos, Ordenacao: domain.OrdenarPedidosPorCriacao},
+	}
+
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := repo.Listar(context.Background(), filtro); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
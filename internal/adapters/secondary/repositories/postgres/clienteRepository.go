@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"soat-fiap/internal/core/domain"
+	"time"
+)
+
+type ClienteRepository struct {
+	db *sql.DB
+}
+
+func NovoClienteRepository(db *sql.DB) *ClienteRepository {
+	return &ClienteRepository{
+		db: db,
+	}
+}
+
+// Criar usa ON CONFLICT para detectar o CPF duplicado sem depender da
+// mensagem de erro da constraint: nenhuma linha inserida indica conflito.
+func (r *ClienteRepository) Criar(ctx context.Context, cliente *domain.Cliente) error {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO clientes (id, nome, cpf, email, telefone, created_at, updated_at, versao)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (cpf) DO NOTHING
+	`,
+		cliente.ID,
+		cliente.Nome,
+		cliente.CPF,
+		cliente.Email,
+		cliente.Telefone,
+		cliente.CreatedAt,
+		cliente.UpdatedAt,
+		cliente.Versao,
+	)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrCPFDuplicado
+	}
+
+	return nil
+}
+
+func (r *ClienteRepository) BuscarPorID(ctx context.Context, id string) (*domain.Cliente, error) {
+	return r.buscar(ctx, "id", id)
+}
+
+func (r *ClienteRepository) BuscarPorCPF(ctx context.Context, cpf string) (*domain.Cliente, error) {
+	return r.buscar(ctx, "cpf", cpf)
+}
+
+func (r *ClienteRepository) buscar(ctx context.Context, coluna, valor string) (*domain.Cliente, error) {
+	var cliente domain.Cliente
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
+		FROM clientes
+		WHERE `+coluna+` = $1 AND deleted_at IS NULL
+	`, valor).Scan(
+		&cliente.ID,
+		&cliente.Nome,
+		&cliente.CPF,
+		&cliente.Email,
+		&cliente.Telefone,
+		&cliente.CreatedAt,
+		&cliente.UpdatedAt,
+		&cliente.Versao,
+		&cliente.DeletedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, traduzirErro(err)
+	}
+
+	return &cliente, nil
+}
+
+var colunasOrdenacaoClientes = map[string]colunaOrdenacao{
+	domain.OrdenarClientesPorNome:    {nome: "nome", converter: cursorTexto},
+	domain.OrdenarClientesPorCriacao: {nome: "created_at", converter: cursorTempo},
+}
+
+func (r *ClienteRepository) Listar(ctx context.Context, filtro domain.FiltroClientes) (*domain.Pagina[*domain.Cliente], error) {
+	var c consulta
+	if filtro.Nome != "" {
+		c.onde("nome ILIKE " + c.param("%"+filtro.Nome+"%"))
+	}
+	if filtro.Removidos {
+		c.onde("deleted_at IS NOT NULL")
+	} else {
+		c.onde("deleted_at IS NULL")
+	}
+	ordenacao, err := c.paginar(filtro.Paginacao, colunasOrdenacaoClientes)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
+		FROM clientes
+		`+c.clausulaWhere()+`
+		`+ordenacao, c.args...)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	var clientes []*domain.Cliente
+
+	for rows.Next() {
+		var cliente domain.Cliente
+
+		err := rows.Scan(
+			&cliente.ID,
+			&cliente.Nome,
+			&cliente.CPF,
+			&cliente.Email,
+			&cliente.Telefone,
+			&cliente.CreatedAt,
+			&cliente.UpdatedAt,
+			&cliente.Versao,
+			&cliente.DeletedAt,
+		)
+		if err != nil {
+			return nil, traduzirErro(err)
+		}
+
+		clientes = append(clientes, &cliente)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, traduzirErro(err)
+	}
+
+	return domain.NovaPagina(clientes, filtro.Paginacao, func(c *domain.Cliente) (string, string) {
+		return c.ValorOrdenacao(filtro.Ordenacao), c.ID
+	}), nil
+}
+
+func (r *ClienteRepository) Atualizar(ctx context.Context, cliente *domain.Cliente) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE clientes
+		SET nome = $1, cpf = $2, email = $3, telefone = $4, updated_at = $5, versao = versao + 1
+		WHERE id = $6 AND versao = $7 AND deleted_at IS NULL
+	`,
+		cliente.Nome,
+		cliente.CPF,
+		cliente.Email,
+		cliente.Telefone,
+		cliente.UpdatedAt,
+		cliente.ID,
+		cliente.Versao,
+	)
+	if err = traduzirErro(err); errors.Is(err, domain.ErrConflito) {
+		return domain.ErrCPFDuplicado
+	}
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return versaoOuNaoEncontrado(ctx, r.db, "clientes", cliente.ID, domain.ErrClienteNaoEncontrado)
+	}
+
+	cliente.Versao++
+	return nil
+}
+
+func (r *ClienteRepository) Deletar(ctx context.Context, id string) error {
+	return r.marcarRemocao(ctx, `
+		UPDATE clientes
+		SET deleted_at = $1, versao = versao + 1
+		WHERE id = $2 AND deleted_at IS NULL
+	`, time.Now().UTC(), id)
+}
+
+func (r *ClienteRepository) Restaurar(ctx context.Context, id string) error {
+	return r.marcarRemocao(ctx, `
+		UPDATE clientes
+		SET deleted_at = NULL, versao = versao + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id)
+}
+
+// marcarRemocao executa a remoção lógica ou a restauração; nenhuma linha
+// afetada significa que não há cliente no estado esperado.
+func (r *ClienteRepository) marcarRemocao(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrClienteNaoEncontrado
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"soat-fiap/internal/core/domain"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	pgErroViolacaoUnica = "23505"
+	// Classe 08: exceções de conexão.
+	pgClasseConexao = "08"
+	// Servidor encerrando ou sem conexões disponíveis.
+	pgErroEncerrandoAdmin   = "57P01"
+	pgErroEncerrandoCrash   = "57P02"
+	pgErroMuitasConexoes    = "53300"
+	pgErroCancelamentoQuery = "57014"
+)
+
+// traduzirErro converte erros do driver PostgreSQL nas categorias de erro do
+// domínio. Erros desconhecidos são devolvidos sem alteração.
+func traduzirErro(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgErroViolacaoUnica:
+			return &domain.Erro{
+				Tipo:     domain.ErrConflito,
+				Codigo:   "REGISTRO_DUPLICADO",
+				Mensagem: "registro duplicado",
+				Causa:    err,
+			}
+		case strings.HasPrefix(pgErr.Code, pgClasseConexao),
+			pgErr.Code == pgErroEncerrandoAdmin,
+			pgErr.Code == pgErroEncerrandoCrash,
+			pgErr.Code == pgErroMuitasConexoes,
+			pgErr.Code == pgErroCancelamentoQuery:
+			return domain.NovoErroIndisponivel(err)
+		}
+	}
+
+	var netErr net.Error
+	var connectErr *pgconn.ConnectError
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		pgconn.Timeout(err) {
+		return domain.NovoErroIndisponivel(err)
+	}
+
+	return err
+}
+
+// executor é atendido por *sql.DB e *sql.Tx, para que a mesma consulta rode
+// dentro ou fora de uma transação.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// tabelasComRemocaoLogica são as tabelas em que um registro com deleted_at
+// preenchido conta como inexistente.
+var tabelasComRemocaoLogica = map[string]bool{"clientes": true, "produtos": true}
+
+// versaoOuNaoEncontrado explica um UPDATE ... WHERE versao = ? que não afetou
+// nenhuma linha: o registro foi removido (inclusive logicamente) ou outra
+// requisição o alterou antes.
+func versaoOuNaoEncontrado(ctx context.Context, db executor, tabela, id string, naoEncontrado error) error {
+	consulta := "SELECT 1 FROM " + tabela + " WHERE id = $1"
+	if tabelasComRemocaoLogica[tabela] {
+		consulta += " AND deleted_at IS NULL"
+	}
+
+	var existe int
+	err := db.QueryRowContext(ctx, consulta, id).Scan(&existe)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return naoEncontrado
+	case err != nil:
+		return traduzirErro(err)
+	default:
+		return domain.ErrVersaoDesatualizada
+	}
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"fmt"
+	"soat-fiap/internal/core/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// colunaOrdenacao liga um campo de ordenação da API a uma coluna da tabela.
+// converter transforma o valor guardado no cursor no parâmetro da consulta.
+type colunaOrdenacao struct {
+	nome      string
+	converter func(string) (any, error)
+}
+
+func cursorTexto(valor string) (any, error) {
+	return valor, nil
+}
+
+func cursorDecimal(valor string) (any, error) {
+	return strconv.ParseFloat(valor, 64)
+}
+
+func cursorTempo(valor string) (any, error) {
+	return time.Parse(time.RFC3339Nano, valor)
+}
+
+// consulta acumula as condições WHERE e os parâmetros de uma listagem. Os
+// parâmetros são numerados ($1, $2...) na ordem em que são registrados por
+// param, então as condições podem ser montadas em qualquer ordem.
+type consulta struct {
+	condicoes []string
+	args      []any
+}
+
+// param registra um parâmetro e devolve o marcador a usar no SQL.
+func (c *consulta) param(valor any) string {
+	c.args = append(c.args, valor)
+	return "$" + strconv.Itoa(len(c.args))
+}
+
+func (c *consulta) onde(condicao string) {
+	c.condicoes = append(c.condicoes, condicao)
+}
+
+func (c *consulta) clausulaWhere() string {
+	if len(c.condicoes) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(c.condicoes, " AND ")
+}
+
+// paginar aplica a condição de keyset do cursor e devolve ORDER BY e LIMIT.
+// A consulta busca uma linha a mais para indicar se há próxima página.
+func (c *consulta) paginar(p domain.Paginacao, colunas map[string]colunaOrdenacao) (string, error) {
+	coluna := colunas[p.Ordenacao]
+
+	direcao, comparador := "ASC", ">"
+	if p.Decrescente {
+		direcao, comparador = "DESC", "<"
+	}
+
+	cursor, err := p.CursorAtual()
+	if err != nil {
+		return "", err
+	}
+	if cursor != nil {
+		valor, err := coluna.converter(cursor.Valor)
+		if err != nil {
+			return "", domain.NovoErroValidacao("cursor", "INVALIDO", "cursor inválido")
+		}
+		// Comparação de tuplas: (coluna, id) > (valor, id do cursor).
+		c.onde(fmt.Sprintf("(%s, id) %s (%s, %s)", coluna.nome, comparador, c.param(valor), c.param(cursor.ID)))
+	}
+
+	return fmt.Sprintf("ORDER BY %[1]s %[2]s, id %[2]s LIMIT %[3]s", coluna.nome, direcao, c.param(p.Limite+1)), nil
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"soat-fiap/internal/core/domain"
+	"time"
+)
+
+// colunasPreco são as colunas do histórico de preços, na ordem dos Scan.
+const colunasPreco = "id, produto_id, preco, vigente_desde, created_at"
+
+func (r *ProdutoRepository) ListarPrecos(ctx context.Context, produtoID string) ([]*domain.PrecoProduto, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+colunasPreco+`
+		FROM produto_precos
+		WHERE produto_id = $1
+		ORDER BY vigente_desde DESC, id DESC
+	`, produtoID)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	precos := []*domain.PrecoProduto{}
+	for rows.Next() {
+		var preco domain.PrecoProduto
+		if err := rows.Scan(&preco.ID, &preco.ProdutoID, &preco.Preco, &preco.VigenteDesde, &preco.CreatedAt); err != nil {
+			return nil, traduzirErro(err)
+		}
+		precos = append(precos, &preco)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, traduzirErro(err)
+	}
+
+	return precos, nil
+}
+
+func (r *ProdutoRepository) PrecoVigente(ctx context.Context, produtoID string, em time.Time) (*domain.PrecoProduto, error) {
+	var preco domain.PrecoProduto
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT `+colunasPreco+`
+		FROM produto_precos
+		WHERE produto_id = $1 AND vigente_desde <= $2
+		ORDER BY vigente_desde DESC, id DESC
+		LIMIT 1
+	`, produtoID, em).Scan(&preco.ID, &preco.ProdutoID, &preco.Preco, &preco.VigenteDesde, &preco.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, traduzirErro(err)
+	}
+
+	return &preco, nil
+}
+
+func (r *ProdutoRepository) AgendarPreco(ctx context.Context, preco *domain.PrecoProduto) error {
+	return registrarPreco(ctx, r.db, preco)
+}
+
+func (r *ProdutoRepository) CancelarPrecoAgendado(ctx context.Context, produtoID string, id int64, agora time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM produto_precos
+		WHERE id = $1 AND produto_id = $2 AND vigente_desde > $3
+	`, id, produtoID, agora)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrPrecoAgendadoNaoEncontrado
+	}
+
+	return nil
+}
+
+func (r *ProdutoRepository) AplicarPrecosVigentes(ctx context.Context, em time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE produtos
+		SET preco = (SELECT pp.preco FROM produto_precos pp WHERE pp.produto_id = produtos.id AND pp.vigente_desde <= $1 ORDER BY pp.vigente_desde DESC, pp.id DESC LIMIT 1), updated_at = $1, versao = versao + 1
+		WHERE deleted_at IS NULL AND preco <> (SELECT pp.preco FROM produto_precos pp WHERE pp.produto_id = produtos.id AND pp.vigente_desde <= $1 ORDER BY pp.vigente_desde DESC, pp.id DESC LIMIT 1)
+	`, em)
+	if err != nil {
+		return 0, traduzirErro(err)
+	}
+
+	alterados, err := result.RowsAffected()
+	return alterados, traduzirErro(err)
+}
+
+// registrarPreco grava um preço no histórico e preenche o ID gerado.
+func registrarPreco(ctx context.Context, db executor, preco *domain.PrecoProduto) error {
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO produto_precos (produto_id, preco, vigente_desde, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, preco.ProdutoID, preco.Preco, preco.VigenteDesde, preco.CreatedAt).Scan(&preco.ID)
+	return traduzirErro(err)
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"soat-fiap/internal/core/domain"
 	"time"
 )
@@ -18,16 +19,16 @@ func NovoProdutoRepository(db *sql.DB) *ProdutoRepository {
 }
 
 func (r *ProdutoRepository) Criar(ctx context.Context, produto *domain.Produto) error {
-	stmt, err := r.db.PrepareContext(ctx, `
-		INSERT INTO produtos (id, nome, descricao, preco, categoria, disponivel, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	_, err = stmt.ExecContext(ctx,
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO produtos (id, nome, descricao, preco, categoria, disponivel, created_at, updated_at, versao)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
 		produto.ID,
 		produto.Nome,
 		produto.Descricao,
@@ -36,23 +37,32 @@ func (r *ProdutoRepository) Criar(ctx context.Context, produto *domain.Produto)
 		produto.Disponivel,
 		produto.CreatedAt.Format(time.RFC3339),
 		produto.UpdatedAt.Format(time.RFC3339),
+		produto.Versao,
 	)
+	if err != nil {
+		return traduzirErro(err)
+	}
 
-	return err
+	if err := registrarPreco(ctx, tx, &domain.PrecoProduto{ProdutoID: produto.ID, Preco: produto.Preco, VigenteDesde: produto.CreatedAt, CreatedAt: produto.CreatedAt}); err != nil {
+		return err
+	}
+
+	return traduzirErro(tx.Commit())
 }
 
 func (r *ProdutoRepository) BuscarPorID(ctx context.Context, id string) (*domain.Produto, error) {
 	stmt, err := r.db.PrepareContext(ctx, `
-		SELECT id, nome, descricao, preco, categoria, disponivel, created_at, updated_at
+		SELECT id, nome, descricao, preco, categoria, disponivel, created_at, updated_at, versao, deleted_at, imagem
 		FROM produtos
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`)
 	if err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 	defer stmt.Close()
 
 	var produto domain.Produto
+	var imagem sql.NullString
 	var createdAtStr, updatedAtStr string
 
 	err = stmt.QueryRowContext(ctx, id).Scan(
@@ -64,74 +74,62 @@ func (r *ProdutoRepository) BuscarPorID(ctx context.Context, id string) (*domain
 		&produto.Disponivel,
 		&createdAtStr,
 		&updatedAtStr,
+		&produto.Versao,
+		&produto.DeletedAt,
+		&imagem,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 
 	produto.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
 	produto.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAtStr)
+	produto.Imagem = lerImagem(imagem)
 
 	return &produto, nil
 }
 
-func (r *ProdutoRepository) Listar(ctx context.Context) ([]*domain.Produto, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, nome, descricao, preco, categoria, disponivel, created_at, updated_at
-		FROM produtos
-		ORDER BY nome
-	`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var produtos []*domain.Produto
-
-	for rows.Next() {
-		var produto domain.Produto
-		var createdAtStr, updatedAtStr string
-
-		err := rows.Scan(
-			&produto.ID,
-			&produto.Nome,
-			&produto.Descricao,
-			&produto.Preco,
-			&produto.Categoria,
-			&produto.Disponivel,
-			&createdAtStr,
-			&updatedAtStr,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		produto.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
-		produto.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAtStr)
+var colunasOrdenacaoProdutos = map[string]colunaOrdenacao{
+	domain.OrdenarProdutosPorNome:    {nome: "nome", converter: cursorTexto},
+	domain.OrdenarProdutosPorPreco:   {nome: "preco", converter: cursorDecimal},
+	domain.OrdenarProdutosPorCriacao: {nome: "created_at", converter: cursorTempo},
+}
 
-		produtos = append(produtos, &produto)
+func (r *ProdutoRepository) Listar(ctx context.Context, filtro domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error) {
+	var c consulta
+	if filtro.Categoria != "" {
+		c.onde("categoria = ?", filtro.Categoria)
 	}
-
-	if err = rows.Err(); err != nil {
+	if filtro.PrecoMin != nil {
+		c.onde("preco >= ?", *filtro.PrecoMin)
+	}
+	if filtro.PrecoMax != nil {
+		c.onde("preco <= ?", *filtro.PrecoMax)
+	}
+	if filtro.Disponivel != nil {
+		c.onde("disponivel = ?", *filtro.Disponivel)
+	}
+	if filtro.Removidos {
+		c.onde("deleted_at IS NOT NULL")
+	} else {
+		c.onde("deleted_at IS NULL")
+	}
+	ordenacao, err := c.paginar(filtro.Paginacao, colunasOrdenacaoProdutos)
+	if err != nil {
 		return nil, err
 	}
 
-	return produtos, nil
-}
-
-func (r *ProdutoRepository) ListarPorCategoria(ctx context.Context, categoria domain.Categoria) ([]*domain.Produto, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, nome, descricao, preco, categoria, disponivel, created_at, updated_at
+		SELECT id, nome, descricao, preco, categoria, disponivel, created_at, updated_at, versao, deleted_at, imagem
 		FROM produtos
-		WHERE categoria = ?
-		ORDER BY nome
-	`, categoria)
+		`+c.clausulaWhere()+`
+		`+ordenacao, c.args...)
 	if err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 	defer rows.Close()
 
@@ -139,6 +137,7 @@ func (r *ProdutoRepository) ListarPorCategoria(ctx context.Context, categoria do
 
 	for rows.Next() {
 		var produto domain.Produto
+		var imagem sql.NullString
 		var createdAtStr, updatedAtStr string
 
 		err := rows.Scan(
@@ -150,36 +149,57 @@ func (r *ProdutoRepository) ListarPorCategoria(ctx context.Context, categoria do
 			&produto.Disponivel,
 			&createdAtStr,
 			&updatedAtStr,
+			&produto.Versao,
+			&produto.DeletedAt,
+			&imagem,
 		)
 		if err != nil {
-			return nil, err
+			return nil, traduzirErro(err)
 		}
 
 		produto.CreatedAt, _ = time.Parse(time.RFC3339, createdAtStr)
 		produto.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAtStr)
+		produto.Imagem = lerImagem(imagem)
 
 		produtos = append(produtos, &produto)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, err
+		return nil, traduzirErro(err)
 	}
 
-	return produtos, nil
+	return domain.NovaPagina(produtos, filtro.Paginacao, func(p *domain.Produto) (string, string) {
+		return p.ValorOrdenacao(filtro.Ordenacao), p.ID
+	}), nil
 }
 
+// Atualizar registra no histórico o novo preço, vigente desde UpdatedAt,
+// quando ele muda.
 func (r *ProdutoRepository) Atualizar(ctx context.Context, produto *domain.Produto) error {
-	stmt, err := r.db.PrepareContext(ctx, `
-		UPDATE produtos
-		SET nome = ?, descricao = ?, preco = ?, categoria = ?, disponivel = ?, updated_at = ?
-		WHERE id = ?
-	`)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return traduzirErro(err)
+	}
+	defer tx.Rollback()
+
+	var precoAnterior float64
+	err = tx.QueryRowContext(ctx, `
+		SELECT preco
+		FROM produtos
+		WHERE id = ? AND versao = ? AND deleted_at IS NULL
+	`, produto.ID, produto.Versao).Scan(&precoAnterior)
+	if errors.Is(err, sql.ErrNoRows) {
+		return versaoOuNaoEncontrado(ctx, tx, "produtos", produto.ID, domain.ErrProdutoNaoEncontrado)
+	}
+	if err != nil {
+		return traduzirErro(err)
 	}
-	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx,
+	result, err := tx.ExecContext(ctx, `
+		UPDATE produtos
+		SET nome = ?, descricao = ?, preco = ?, categoria = ?, disponivel = ?, updated_at = ?, versao = versao + 1
+		WHERE id = ? AND versao = ? AND deleted_at IS NULL
+	`,
 		produto.Nome,
 		produto.Descricao,
 		produto.Preco,
@@ -187,46 +207,127 @@ func (r *ProdutoRepository) Atualizar(ctx context.Context, produto *domain.Produ
 		produto.Disponivel,
 		produto.UpdatedAt.Format(time.RFC3339),
 		produto.ID,
+		produto.Versao,
 	)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return versaoOuNaoEncontrado(ctx, tx, "produtos", produto.ID, domain.ErrProdutoNaoEncontrado)
 	}
 
+	if produto.Preco != precoAnterior {
+		preco := &domain.PrecoProduto{ProdutoID: produto.ID, Preco: produto.Preco, VigenteDesde: produto.UpdatedAt, CreatedAt: produto.UpdatedAt}
+		if err := registrarPreco(ctx, tx, preco); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return traduzirErro(err)
+	}
+
+	produto.Versao++
 	return nil
 }
 
-func (r *ProdutoRepository) Deletar(ctx context.Context, id string) error {
-	stmt, err := r.db.PrepareContext(ctx, `
-		DELETE FROM produtos
-		WHERE id = ?
-	`)
+// AtualizarImagem grava a chave da imagem do produto, com a mesma
+// conferência de versão de Atualizar.
+func (r *ProdutoRepository) AtualizarImagem(ctx context.Context, produto *domain.Produto) error {
+	var chave sql.NullString
+	if produto.Imagem != nil {
+		chave = sql.NullString{String: produto.Imagem.Chave, Valid: true}
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE produtos
+		SET imagem = ?, updated_at = ?, versao = versao + 1
+		WHERE id = ? AND versao = ? AND deleted_at IS NULL
+	`, chave, produto.UpdatedAt.Format(time.RFC3339), produto.ID, produto.Versao)
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
-	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, id)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return versaoOuNaoEncontrado(ctx, r.db, "produtos", produto.ID, domain.ErrProdutoNaoEncontrado)
+	}
+
+	produto.Versao++
+	return nil
+}
+
+// Deletar confere os pedidos abertos no próprio UPDATE, para que um pedido
+// criado entre a conferência e a remoção não fique com um produto removido.
+func (r *ProdutoRepository) Deletar(ctx context.Context, id string) error {
+	err := r.marcarRemocao(ctx, `
+		UPDATE produtos
+		SET deleted_at = ?, versao = versao + 1
+		WHERE id = ? AND deleted_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1
+			FROM pedido_itens i
+			JOIN pedidos p ON p.id = i.pedido_id
+			WHERE i.produto_id = produtos.id AND p.status <> ?
+		)
+	`, time.Now().UTC().Format(time.RFC3339), id, domain.StatusFinalizado)
+	if !errors.Is(err, domain.ErrProdutoNaoEncontrado) {
 		return err
 	}
 
+	// Nenhuma linha afetada: se o produto continua ativo, foi o pedido aberto
+	// que impediu a remoção.
+	produto, errBusca := r.BuscarPorID(ctx, id)
+	if errBusca != nil {
+		return errBusca
+	}
+	if produto != nil {
+		return domain.ErrProdutoEmPedidoAberto
+	}
+	return err
+}
+
+func (r *ProdutoRepository) Restaurar(ctx context.Context, id string) error {
+	return r.marcarRemocao(ctx, `
+		UPDATE produtos
+		SET deleted_at = NULL, versao = versao + 1
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`, id)
+}
+
+// marcarRemocao executa a remoção lógica ou a restauração; nenhuma linha
+// afetada significa que não há produto no estado esperado.
+func (r *ProdutoRepository) marcarRemocao(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return traduzirErro(err)
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return domain.ErrProdutoNaoEncontrado
 	}
 
 	return nil
 }
+
+func lerImagem(chave sql.NullString) *domain.Imagem {
+	if !chave.Valid {
+		return nil
+	}
+	return &domain.Imagem{Chave: chave.String}
+}
@@ -0,0 +1,272 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"soat-fiap/internal/core/domain"
+	"time"
+)
+
+type ClienteRepository struct {
+	db *sql.DB
+}
+
+func NovoClienteRepository(db *sql.DB) *ClienteRepository {
+	return &ClienteRepository{
+		db: db,
+	}
+}
+
+func (r *ClienteRepository) Criar(ctx context.Context, cliente *domain.Cliente) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		INSERT INTO clientes (id, nome, cpf, email, telefone, created_at, updated_at, versao)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx,
+		cliente.ID,
+		cliente.Nome,
+		cliente.CPF,
+		cliente.Email,
+		cliente.Telefone,
+		formatarTempo(cliente.CreatedAt),
+		formatarTempo(cliente.UpdatedAt),
+		cliente.Versao,
+	)
+	if err = traduzirErro(err); errors.Is(err, domain.ErrConflito) {
+		return domain.ErrCPFDuplicado
+	}
+
+	return err
+}
+
+func (r *ClienteRepository) BuscarPorID(ctx context.Context, id string) (*domain.Cliente, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
+		FROM clientes
+		WHERE id = ? AND deleted_at IS NULL
+	`)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer stmt.Close()
+
+	var cliente domain.Cliente
+	var createdAtStr, updatedAtStr string
+	var deletedAt sql.NullString
+
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&cliente.ID,
+		&cliente.Nome,
+		&cliente.CPF,
+		&cliente.Email,
+		&cliente.Telefone,
+		&createdAtStr,
+		&updatedAtStr,
+		&cliente.Versao,
+		&deletedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, traduzirErro(err)
+	}
+
+	cliente.CreatedAt = lerTempo(createdAtStr)
+	cliente.UpdatedAt = lerTempo(updatedAtStr)
+	cliente.DeletedAt = lerTempoNulo(deletedAt)
+
+	return &cliente, nil
+}
+
+func (r *ClienteRepository) BuscarPorCPF(ctx context.Context, cpf string) (*domain.Cliente, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
+		FROM clientes
+		WHERE cpf = ? AND deleted_at IS NULL
+	`)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer stmt.Close()
+
+	var cliente domain.Cliente
+	var createdAtStr, updatedAtStr string
+	var deletedAt sql.NullString
+
+	err = stmt.QueryRowContext(ctx, cpf).Scan(
+		&cliente.ID,
+		&cliente.Nome,
+		&cliente.CPF,
+		&cliente.Email,
+		&cliente.Telefone,
+		&createdAtStr,
+		&updatedAtStr,
+		&cliente.Versao,
+		&deletedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, traduzirErro(err)
+	}
+
+	cliente.CreatedAt = lerTempo(createdAtStr)
+	cliente.UpdatedAt = lerTempo(updatedAtStr)
+	cliente.DeletedAt = lerTempoNulo(deletedAt)
+
+	return &cliente, nil
+}
+
+var colunasOrdenacaoClientes = map[string]colunaOrdenacao{
+	domain.OrdenarClientesPorNome:    {nome: "nome", converter: cursorTexto},
+	domain.OrdenarClientesPorCriacao: {nome: "created_at", converter: cursorTempo},
+}
+
+func (r *ClienteRepository) Listar(ctx context.Context, filtro domain.FiltroClientes) (*domain.Pagina[*domain.Cliente], error) {
+	var c consulta
+	if filtro.Nome != "" {
+		c.onde("nome LIKE ?", "%"+filtro.Nome+"%")
+	}
+	if filtro.Removidos {
+		c.onde("deleted_at IS NOT NULL")
+	} else {
+		c.onde("deleted_at IS NULL")
+	}
+	ordenacao, err := c.paginar(filtro.Paginacao, colunasOrdenacaoClientes)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, nome, cpf, email, telefone, created_at, updated_at, versao, deleted_at
+		FROM clientes
+		`+c.clausulaWhere()+`
+		`+ordenacao, c.args...)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	var clientes []*domain.Cliente
+
+	for rows.Next() {
+		var cliente domain.Cliente
+		var createdAtStr, updatedAtStr string
+		var deletedAt sql.NullString
+
+		err := rows.Scan(
+			&cliente.ID,
+			&cliente.Nome,
+			&cliente.CPF,
+			&cliente.Email,
+			&cliente.Telefone,
+			&createdAtStr,
+			&updatedAtStr,
+			&cliente.Versao,
+			&deletedAt,
+		)
+		if err != nil {
+			return nil, traduzirErro(err)
+		}
+
+		cliente.CreatedAt = lerTempo(createdAtStr)
+		cliente.UpdatedAt = lerTempo(updatedAtStr)
+		cliente.DeletedAt = lerTempoNulo(deletedAt)
+
+		clientes = append(clientes, &cliente)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, traduzirErro(err)
+	}
+
+	return domain.NovaPagina(clientes, filtro.Paginacao, func(c *domain.Cliente) (string, string) {
+		return c.ValorOrdenacao(filtro.Ordenacao), c.ID
+	}), nil
+}
+
+func (r *ClienteRepository) Atualizar(ctx context.Context, cliente *domain.Cliente) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE clientes
+		SET nome = ?, cpf = ?, email = ?, telefone = ?, updated_at = ?, versao = versao + 1
+		WHERE id = ? AND versao = ? AND deleted_at IS NULL
+	`)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx,
+		cliente.Nome,
+		cliente.CPF,
+		cliente.Email,
+		cliente.Telefone,
+		formatarTempo(cliente.UpdatedAt),
+		cliente.ID,
+		cliente.Versao,
+	)
+	if err = traduzirErro(err); errors.Is(err, domain.ErrConflito) {
+		return domain.ErrCPFDuplicado
+	}
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return versaoOuNaoEncontrado(ctx, r.db, "clientes", cliente.ID, domain.ErrClienteNaoEncontrado)
+	}
+
+	cliente.Versao++
+	return nil
+}
+
+func (r *ClienteRepository) Deletar(ctx context.Context, id string) error {
+	return r.marcarRemocao(ctx, `
+		UPDATE clientes
+		SET deleted_at = ?, versao = versao + 1
+		WHERE id = ? AND deleted_at IS NULL
+	`, formatarTempo(time.Now()), id)
+}
+
+func (r *ClienteRepository) Restaurar(ctx context.Context, id string) error {
+	return r.marcarRemocao(ctx, `
+		UPDATE clientes
+		SET deleted_at = NULL, versao = versao + 1
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`, id)
+}
+
+// marcarRemocao executa a remoção lógica ou a restauração; nenhuma linha
+// afetada significa que não há cliente no estado esperado.
+func (r *ClienteRepository) marcarRemocao(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrClienteNaoEncontrado
+	}
+
+	return nil
+}
@@ -0,0 +1,339 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/pkg/logger"
+	"strings"
+)
+
+type PedidoRepository struct {
+	db *sql.DB
+}
+
+func NovoPedidoRepository(db *sql.DB) *PedidoRepository {
+	return &PedidoRepository{
+		db: db,
+	}
+}
+
+func (r *PedidoRepository) Criar(ctx context.Context, pedido *domain.Pedido) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO pedidos (id, cliente_id, valor_total, status, created_at, updated_at, versao)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx,
+		pedido.ID,
+		pedido.ClienteID,
+		pedido.ValorTotal,
+		pedido.Status,
+		formatarTempo(pedido.CreatedAt),
+		formatarTempo(pedido.UpdatedAt),
+		pedido.Versao,
+	)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	stmtItem, err := tx.PrepareContext(ctx, `
+		INSERT INTO pedido_itens (pedido_id, produto_id, nome, preco, quantidade, observacao)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer stmtItem.Close()
+
+	for _, item := range pedido.Itens {
+		_, err = stmtItem.ExecContext(ctx,
+			pedido.ID,
+			item.ProdutoID,
+			item.Nome,
+			item.Preco,
+			item.Quantidade,
+			item.Observacao,
+		)
+		if err != nil {
+			return traduzirErro(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return traduzirErro(err)
+	}
+
+	logger.DoContexto(ctx).Debug("pedido gravado", "pedido_id", pedido.ID, "itens", len(pedido.Itens))
+	return nil
+}
+
+func (r *PedidoRepository) BuscarPorID(ctx context.Context, id string) (*domain.Pedido, error) {
+	stmt, err := r.db.PrepareContext(ctx, `
+		SELECT id, cliente_id, valor_total, status, created_at, updated_at, versao
+		FROM pedidos
+		WHERE id = ?
+	`)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer stmt.Close()
+
+	var pedido domain.Pedido
+	var createdAtStr, updatedAtStr string
+	var clienteID sql.NullString
+
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&pedido.ID,
+		&clienteID,
+		&pedido.ValorTotal,
+		&pedido.Status,
+		&createdAtStr,
+		&updatedAtStr,
+		&pedido.Versao,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, traduzirErro(err)
+	}
+
+	if clienteID.Valid {
+		pedido.ClienteID = &clienteID.String
+	}
+
+	pedido.CreatedAt = lerTempo(createdAtStr)
+	pedido.UpdatedAt = lerTempo(updatedAtStr)
+
+	if err := r.carregarItens(ctx, []*domain.Pedido{&pedido}); err != nil {
+		return nil, err
+	}
+
+	return &pedido, nil
+}
+
+var colunasOrdenacaoPedidos = map[string]colunaOrdenacao{
+	domain.OrdenarPedidosPorCriacao:     {nome: "created_at", converter: cursorTempo},
+	domain.OrdenarPedidosPorAtualizacao: {nome: "updated_at", converter: cursorTempo},
+	domain.OrdenarPedidosPorValor:       {nome: "valor_total", converter: cursorDecimal},
+}
+
+func (r *PedidoRepository) Listar(ctx context.Context, filtro domain.FiltroPedidos) (*domain.Pagina[*domain.Pedido], error) {
+	var c consulta
+	if len(filtro.Status) > 0 {
+		marcadores := strings.Repeat("?, ", len(filtro.Status)-1) + "?"
+		args := make([]any, len(filtro.Status))
+		for i, status := range filtro.Status {
+			args[i] = status
+		}
+		c.onde("status IN ("+marcadores+")", args...)
+	}
+	if filtro.ClienteID != "" {
+		c.onde("cliente_id = ?", filtro.ClienteID)
+	}
+	if filtro.De != nil {
+		c.onde("created_at >= ?", formatarTempo(*filtro.De))
+	}
+	if filtro.Ate != nil {
+		c.onde("created_at < ?", formatarTempo(*filtro.Ate))
+	}
+	ordenacao, err := c.paginar(filtro.Paginacao, colunasOrdenacaoPedidos)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, cliente_id, valor_total, status, created_at, updated_at, versao
+		FROM pedidos
+		`+c.clausulaWhere()+`
+		`+ordenacao, c.args...)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	pedidos, err := r.processarResultados(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.carregarItens(ctx, pedidos); err != nil {
+		return nil, err
+	}
+
+	return domain.NovaPagina(pedidos, filtro.Paginacao, func(p *domain.Pedido) (string, string) {
+		return p.ValorOrdenacao(filtro.Ordenacao), p.ID
+	}), nil
+}
+
+func (r *PedidoRepository) Atualizar(ctx context.Context, pedido *domain.Pedido) error {
+	stmt, err := r.db.PrepareContext(ctx, `
+		UPDATE pedidos
+		SET status = ?, updated_at = ?, versao = versao + 1
+		WHERE id = ? AND versao = ?
+	`)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx,
+		pedido.Status,
+		formatarTempo(pedido.UpdatedAt),
+		pedido.ID,
+		pedido.Versao,
+	)
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return traduzirErro(err)
+	}
+
+	if rowsAffected == 0 {
+		return versaoOuNaoEncontrado(ctx, r.db, "pedidos", pedido.ID, domain.ErrPedidoNaoEncontrado)
+	}
+
+	pedido.Versao++
+	return nil
+}
+
+// processarResultados lê todas as linhas de pedidos. Os itens são carregados
+// depois, em lote, por carregarItens, para não abrir uma consulta por pedido
+// enquanto o cursor externo ainda segura a conexão.
+func (r *PedidoRepository) processarResultados(rows *sql.Rows) ([]*domain.Pedido, error) {
+	var pedidos []*domain.Pedido
+
+	for rows.Next() {
+		var pedido domain.Pedido
+		var createdAtStr, updatedAtStr string
+		var clienteID sql.NullString
+
+		err := rows.Scan(
+			&pedido.ID,
+			&clienteID,
+			&pedido.ValorTotal,
+			&pedido.Status,
+			&createdAtStr,
+			&updatedAtStr,
+			&pedido.Versao,
+		)
+		if err != nil {
+			return nil, traduzirErro(err)
+		}
+
+		if clienteID.Valid {
+			pedido.ClienteID = &clienteID.String
+		}
+
+		pedido.CreatedAt = lerTempo(createdAtStr)
+		pedido.UpdatedAt = lerTempo(updatedAtStr)
+
+		pedidos = append(pedidos, &pedido)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, traduzirErro(err)
+	}
+
+	return pedidos, nil
+}
+
+// carregarItens busca os itens de todos os pedidos em uma única consulta.
+func (r *PedidoRepository) carregarItens(ctx context.Context, pedidos []*domain.Pedido) error {
+	if len(pedidos) == 0 {
+		return nil
+	}
+
+	porID := make(map[string]*domain.Pedido, len(pedidos))
+	args := make([]any, len(pedidos))
+	for i, pedido := range pedidos {
+		porID[pedido.ID] = pedido
+		args[i] = pedido.ID
+	}
+	marcadores := strings.Repeat("?, ", len(pedidos)-1) + "?"
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pedido_id, produto_id, nome, preco, quantidade, observacao
+		FROM pedido_itens
+		WHERE pedido_id IN (`+marcadores+`)
+		ORDER BY pedido_id, produto_id
+	`, args...)
+	if err != nil {
+		return traduzirErro(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pedidoID string
+		var item domain.ItemPedido
+		var observacao sql.NullString
+
+		err := rows.Scan(
+			&pedidoID,
+			&item.ProdutoID,
+			&item.Nome,
+			&item.Preco,
+			&item.Quantidade,
+			&observacao,
+		)
+		if err != nil {
+			return traduzirErro(err)
+		}
+
+		if observacao.Valid {
+			item.Observacao = observacao.String
+		}
+
+		if pedido, ok := porID[pedidoID]; ok {
+			pedido.Itens = append(pedido.Itens, item)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return traduzirErro(err)
+	}
+
+	logger.DoContexto(ctx).Debug("itens carregados em lote", "pedidos", len(pedidos))
+	return nil
+}
+
+func (r *PedidoRepository) ContarPorStatus(ctx context.Context) (map[domain.StatusPedido]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT status, COUNT(*)
+		FROM pedidos
+		GROUP BY status
+	`)
+	if err != nil {
+		return nil, traduzirErro(err)
+	}
+	defer rows.Close()
+
+	contagem := make(map[domain.StatusPedido]int)
+	for rows.Next() {
+		var status domain.StatusPedido
+		var total int
+		if err := rows.Scan(&status, &total); err != nil {
+			return nil, traduzirErro(err)
+		}
+		contagem[status] = total
+	}
+	if err := rows.Err(); err != nil {
+		return nil, traduzirErro(err)
+	}
+	return contagem, nil
+}
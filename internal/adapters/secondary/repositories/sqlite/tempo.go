@@ -0,0 +1,30 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+// formatoTempo grava as datas em UTC com largura fixa. O SQLite não tem tipo
+// de data: as colunas são texto, e comparações e ORDER BY só coincidem com a
+// ordem cronológica se todos os valores tiverem o mesmo formato e fuso. Por
+// isso não se usa RFC3339Nano, que omite os zeros finais da fração.
+const formatoTempo = "2006-01-02T15:04:05.000000000Z07:00"
+
+func formatarTempo(t time.Time) string {
+	return t.UTC().Format(formatoTempo)
+}
+
+func lerTempo(valor string) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, valor)
+	return t
+}
+
+// lerTempoNulo lê uma coluna de data opcional, como deleted_at.
+func lerTempoNulo(valor sql.NullString) *time.Time {
+	if !valor.Valid {
+		return nil
+	}
+	t := lerTempo(valor.String)
+	return &t
+}
@@ -0,0 +1,80 @@
+package saude
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/pkg/database"
+	"soat-fiap/pkg/logger"
+)
+
+// VerificadorBanco checa se o banco responde dentro do prazo e se todas as
+// migrações embutidas no binário já foram aplicadas.
+type VerificadorBanco struct {
+	db       *sql.DB
+	migrador *database.Migrador
+	timeout  time.Duration
+}
+
+func NovoVerificadorBanco(db *sql.DB, driver string, timeout time.Duration) (*VerificadorBanco, error) {
+	migrador, err := database.NovoMigrador(db, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerificadorBanco{
+		db:       db,
+		migrador: migrador,
+		timeout:  timeout,
+	}, nil
+}
+
+func (v *VerificadorBanco) Nome() string {
+	return "database"
+}
+
+// Verificar devolve mensagens fixas em Erro, já que a resposta da prontidão é
+// pública; o erro do driver, que pode trazer host, usuário e banco, vai só
+// para o log.
+func (v *VerificadorBanco) Verificar(ctx context.Context) domain.VerificacaoSaude {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	stats := v.db.Stats()
+	resultado := domain.VerificacaoSaude{
+		Status: domain.SaudeOK,
+		Detalhes: map[string]any{
+			"conexoes_abertas": stats.OpenConnections,
+			"conexoes_em_uso":  stats.InUse,
+			"conexoes_ociosas": stats.Idle,
+			"max_conexoes":     stats.MaxOpenConnections,
+			"esperas":          stats.WaitCount,
+			"tempo_espera":     stats.WaitDuration.String(),
+		},
+	}
+
+	if err := v.db.PingContext(ctx); err != nil {
+		logger.DoContexto(ctx).Warn("banco indisponível na verificação de saúde", "erro", err)
+		resultado.Status = domain.SaudeFalha
+		resultado.Erro = "banco indisponível"
+		return resultado
+	}
+
+	pendentes, err := v.migrador.Pendentes(ctx)
+	if err != nil {
+		logger.DoContexto(ctx).Warn("erro ao verificar as migrações na verificação de saúde", "erro", err)
+		resultado.Status = domain.SaudeFalha
+		resultado.Erro = "não foi possível verificar o esquema"
+		return resultado
+	}
+	resultado.Detalhes["migracoes_pendentes"] = pendentes
+	if pendentes > 0 {
+		resultado.Status = domain.SaudeFalha
+		resultado.Erro = fmt.Sprintf("esquema desatualizado: %d migrações pendentes", pendentes)
+	}
+
+	return resultado
+}
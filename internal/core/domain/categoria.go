@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// Categoria é o código de uma categoria, gravado em cada produto. As
+// categorias são cadastradas pela API (CategoriaProduto); o código não muda
+// depois de criado.
+type Categoria string
+
+const (
+	TamanhoMaximoCodigoCategoria = 20
+	TamanhoMaximoNomeCategoria   = 100
+	TamanhoMaximoIconeCategoria  = 255
+)
+
+var (
+	// Letras maiúsculas (inclusive acentuadas, como em CAFÉ), dígitos e _.
+	padraoCodigoCategoria = regexp.MustCompile(`^[\p{Lu}\p{Nd}_]+$`)
+	// Etiqueta de idioma BCP 47 simplificada: pt, en, es, pt-BR, zh-Hant.
+	padraoIdioma = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+)
+
+// CategoriaProduto é uma categoria do cardápio. Ordem define a posição na
+// exibição (menor primeiro, empates pelo código) e Traducoes guarda o nome em
+// outros idiomas, indexado pela etiqueta do idioma. Categorias inativas
+// continuam nos produtos já cadastrados, mas não recebem produtos novos.
+type CategoriaProduto struct {
+	Codigo    Categoria         `json:"codigo" example:"LANCHE"`
+	Nome      string            `json:"nome" example:"Lanches"`
+	Icone     string            `json:"icone" example:"https://cdn.exemplo.com/icones/lanche.svg"`
+	Ordem     int               `json:"ordem" example:"1"`
+	Ativa     bool              `json:"ativa" example:"true"`
+	Traducoes map[string]string `json:"traducoes" example:"en:Burgers"`
+	CreatedAt time.Time         `json:"created_at" example:"2025-01-15T12:30:00Z"`
+	UpdatedAt time.Time         `json:"updated_at" example:"2025-01-15T12:30:00Z"`
+	Versao    int64             `json:"versao" example:"1"`
+}
+
+func NovaCategoriaProduto(codigo Categoria, nome, icone string, ordem int, traducoes map[string]string) (*CategoriaProduto, error) {
+	if traducoes == nil {
+		traducoes = map[string]string{}
+	}
+	categoria := &CategoriaProduto{
+		Codigo:    codigo,
+		Nome:      nome,
+		Icone:     icone,
+		Ordem:     ordem,
+		Ativa:     true,
+		Traducoes: traducoes,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Versao:    1,
+	}
+
+	if err := categoria.Validar(); err != nil {
+		return nil, err
+	}
+
+	return categoria, nil
+}
+
+func (c *CategoriaProduto) Validar() error {
+	var erros ErrosValidacao
+
+	switch {
+	case c.Codigo == "":
+		erros.Adicionar("codigo", "OBRIGATORIO", "código não pode ser vazio")
+	case utf8.RuneCountInString(string(c.Codigo)) > TamanhoMaximoCodigoCategoria:
+		erros.Adicionar("codigo", "FORA_DO_INTERVALO", "código deve ter no máximo 20 caracteres")
+	case !padraoCodigoCategoria.MatchString(string(c.Codigo)):
+		erros.Adicionar("codigo", "INVALIDO", "código deve ter só letras maiúsculas, dígitos e _")
+	}
+
+	if c.Nome == "" {
+		erros.Adicionar("nome", "OBRIGATORIO", "nome não pode ser vazio")
+	} else if utf8.RuneCountInString(c.Nome) > TamanhoMaximoNomeCategoria {
+		erros.Adicionar("nome", "FORA_DO_INTERVALO", "nome deve ter no máximo 100 caracteres")
+	}
+
+	if utf8.RuneCountInString(c.Icone) > TamanhoMaximoIconeCategoria {
+		erros.Adicionar("icone", "FORA_DO_INTERVALO", "ícone deve ter no máximo 255 caracteres")
+	}
+
+	if c.Ordem < 0 {
+		erros.Adicionar("ordem", "FORA_DO_INTERVALO", "ordem não pode ser negativa")
+	}
+
+	for _, idioma := range slices.Sorted(maps.Keys(c.Traducoes)) {
+		nome := c.Traducoes[idioma]
+		campo := "traducoes." + idioma
+		switch {
+		case !padraoIdioma.MatchString(idioma):
+			erros.Adicionar(campo, "IDIOMA_INVALIDO", "idioma deve ser uma etiqueta como en, es ou pt-BR")
+		case nome == "":
+			erros.Adicionar(campo, "OBRIGATORIO", "tradução não pode ser vazia")
+		case utf8.RuneCountInString(nome) > TamanhoMaximoNomeCategoria:
+			erros.Adicionar(campo, "FORA_DO_INTERVALO", "tradução deve ter no máximo 100 caracteres")
+		}
+	}
+
+	return erros.Erro()
+}
+
+// FiltroCategorias são os critérios de listagem de categorias; Ativa nil não
+// filtra.
+type FiltroCategorias struct {
+	Ativa *bool
+}
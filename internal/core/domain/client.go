@@ -1,19 +1,22 @@
 package domain
 
 import (
-	"errors"
 	"regexp"
 	"time"
 )
 
 type Cliente struct {
-	ID        string    `json:"id"`
-	Nome      string    `json:"nome"`
-	CPF       string    `json:"cpf"`
-	Email     string    `json:"email"`
-	Telefone  string    `json:"telefone"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string    `json:"id" example:"c1552170-1909-443d-bebe-5f71e51ddd17"`
+	Nome      string    `json:"nome" example:"Maria Silva"`
+	CPF       string    `json:"cpf" example:"52998224725"`
+	Email     string    `json:"email" example:"maria@example.com"`
+	Telefone  string    `json:"telefone" example:"11987654321"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-15T12:30:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-15T12:30:00Z"`
+	Versao    int64     `json:"versao" example:"1"`
+	// DeletedAt só é preenchido nos clientes removidos, listados pela
+	// administração.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2025-02-01T09:00:00Z" extensions:"x-nullable"`
 }
 
 func NovoCliente(id, nome, cpf, email, telefone string) (*Cliente, error) {
@@ -25,6 +28,7 @@ func NovoCliente(id, nome, cpf, email, telefone string) (*Cliente, error) {
 		Telefone:  telefone,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Versao:    1,
 	}
 
 	err := cliente.Validar()
@@ -36,23 +40,25 @@ func NovoCliente(id, nome, cpf, email, telefone string) (*Cliente, error) {
 }
 
 func (c *Cliente) Validar() error {
+	var erros ErrosValidacao
+
 	if c.Nome == "" {
-		return errors.New("nome não pode ser vazio")
+		erros.Adicionar("nome", "OBRIGATORIO", "nome não pode ser vazio")
 	}
 
 	if !ValidarCPF(c.CPF) {
-		return errors.New("CPF inválido")
+		erros.Adicionar("cpf", "INVALIDO", "CPF inválido")
 	}
 
 	if !ValidarEmail(c.Email) {
-		return errors.New("email inválido")
+		erros.Adicionar("email", "INVALIDO", "email inválido")
 	}
 
 	if c.Telefone == "" {
-		return errors.New("telefone não pode ser vazio")
+		erros.Adicionar("telefone", "OBRIGATORIO", "telefone não pode ser vazio")
 	}
 
-	return nil
+	return erros.Erro()
 }
 
 func ValidarCPF(cpf string) bool {
@@ -112,3 +118,32 @@ func ValidarEmail(email string) bool {
 	re := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
 	return re.MatchString(email)
 }
+
+const (
+	OrdenarClientesPorNome    = "nome"
+	OrdenarClientesPorCriacao = "created_at"
+)
+
+// FiltroClientes são os critérios de listagem de clientes. Nome filtra por
+// trecho do nome. Removidos troca os clientes ativos pelos removidos.
+type FiltroClientes struct {
+	Paginacao
+	Nome      string
+	Removidos bool
+}
+
+func (f *FiltroClientes) Validar() error {
+	var erros ErrosValidacao
+	f.normalizar(&erros, OrdenarClientesPorNome, OrdenarClientesPorNome, OrdenarClientesPorCriacao)
+	return erros.Erro()
+}
+
+// ValorOrdenacao retorna o valor do campo de ordenação usado para montar o cursor.
+func (c *Cliente) ValorOrdenacao(campo string) string {
+	switch campo {
+	case OrdenarClientesPorCriacao:
+		return formatarValorOrdenacao(c.CreatedAt)
+	default:
+		return formatarValorOrdenacao(c.Nome)
+	}
+}
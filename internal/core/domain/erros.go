@@ -0,0 +1,115 @@
+package domain
+
+import "errors"
+
+// Categorias de erro do domínio. Os adaptadores devem classificar erros com
+// errors.Is contra estes valores, nunca pela mensagem.
+var (
+	ErrNaoEncontrado = errors.New("recurso não encontrado")
+	ErrValidacao     = errors.New("dados inválidos")
+	ErrConflito      = errors.New("conflito com o estado atual do recurso")
+	ErrIndisponivel  = errors.New("serviço temporariamente indisponível")
+	// ErrPrecondicao indica que a versão do recurso informada pelo cliente
+	// (ou lida pelo serviço) não é mais a atual.
+	ErrPrecondicao = errors.New("pré-condição não atendida")
+)
+
+var (
+	ErrClienteNaoEncontrado       = NovoErroNaoEncontrado("CLIENTE_NAO_ENCONTRADO", "cliente não encontrado")
+	ErrProdutoNaoEncontrado       = NovoErroNaoEncontrado("PRODUTO_NAO_ENCONTRADO", "produto não encontrado")
+	ErrPedidoNaoEncontrado        = NovoErroNaoEncontrado("PEDIDO_NAO_ENCONTRADO", "pedido não encontrado")
+	ErrPrecoAgendadoNaoEncontrado = NovoErroNaoEncontrado("PRECO_AGENDADO_NAO_ENCONTRADO", "preço agendado não encontrado")
+	ErrCategoriaNaoEncontrada     = NovoErroNaoEncontrado("CATEGORIA_NAO_ENCONTRADA", "categoria não encontrada")
+	ErrCPFDuplicado               = NovoErroConflito("CPF_DUPLICADO", "já existe um cliente com este CPF")
+	ErrClienteNaoRemovido         = NovoErroConflito("CLIENTE_NAO_REMOVIDO", "cliente não está removido")
+	ErrProdutoNaoRemovido         = NovoErroConflito("PRODUTO_NAO_REMOVIDO", "produto não está removido")
+	ErrProdutoEmPedidoAberto      = NovoErroConflito("PRODUTO_EM_PEDIDO_ABERTO", "produto faz parte de pedidos ainda não finalizados")
+	ErrCategoriaDuplicada         = NovoErroConflito("CATEGORIA_DUPLICADA", "já existe uma categoria com este código")
+	ErrCategoriaEmUso             = NovoErroConflito("CATEGORIA_EM_USO", "a categoria tem produtos; desative-a em vez de removê-la")
+	ErrVersaoDesatualizada        = NovoErroPrecondicao("VERSAO_DESATUALIZADA", "o recurso foi alterado por outra requisição; busque a versão atual e tente novamente")
+)
+
+// ErroCampo descreve uma violação de validação em um campo específico.
+type ErroCampo struct {
+	Campo    string `json:"campo" example:"itens[0].quantidade"`
+	Codigo   string `json:"codigo" example:"DEVE_SER_POSITIVO"`
+	Mensagem string `json:"mensagem" example:"quantidade deve ser maior que zero"`
+}
+
+// Erro é o erro tipado do domínio. Tipo é uma das categorias acima e Codigo
+// é um identificador estável que pode ser exposto aos clientes da API.
+type Erro struct {
+	Tipo     error
+	Codigo   string
+	Mensagem string
+	Campos   []ErroCampo
+	Causa    error
+}
+
+func (e *Erro) Error() string {
+	if e.Causa != nil {
+		return e.Mensagem + ": " + e.Causa.Error()
+	}
+	return e.Mensagem
+}
+
+func (e *Erro) Is(target error) bool {
+	return target == e.Tipo
+}
+
+func (e *Erro) Unwrap() error {
+	return e.Causa
+}
+
+func NovoErroNaoEncontrado(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: ErrNaoEncontrado, Codigo: codigo, Mensagem: mensagem}
+}
+
+func NovoErroValidacao(campo, codigo, mensagem string) *Erro {
+	return &Erro{
+		Tipo:     ErrValidacao,
+		Codigo:   "VALIDACAO",
+		Mensagem: mensagem,
+		Campos:   []ErroCampo{{Campo: campo, Codigo: codigo, Mensagem: mensagem}},
+	}
+}
+
+// ErrosValidacao acumula as violações encontradas durante uma validação para
+// que todas sejam devolvidas de uma vez.
+type ErrosValidacao struct {
+	campos []ErroCampo
+}
+
+func (v *ErrosValidacao) Adicionar(campo, codigo, mensagem string) {
+	v.campos = append(v.campos, ErroCampo{Campo: campo, Codigo: codigo, Mensagem: mensagem})
+}
+
+// Erro retorna nil quando não há violações.
+func (v *ErrosValidacao) Erro() error {
+	if len(v.campos) == 0 {
+		return nil
+	}
+	return &Erro{
+		Tipo:     ErrValidacao,
+		Codigo:   "VALIDACAO",
+		Mensagem: "um ou mais campos são inválidos",
+		Campos:   v.campos,
+	}
+}
+
+func NovoErroConflito(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: ErrConflito, Codigo: codigo, Mensagem: mensagem}
+}
+
+func NovoErroPrecondicao(codigo, mensagem string) *Erro {
+	return &Erro{Tipo: ErrPrecondicao, Codigo: codigo, Mensagem: mensagem}
+}
+
+func NovoErroIndisponivel(causa error) *Erro {
+	return &Erro{
+		Tipo:     ErrIndisponivel,
+		Codigo:   "SERVICO_INDISPONIVEL",
+		Mensagem: "banco de dados indisponível",
+		Causa:    causa,
+	}
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"path"
+	"strings"
+)
+
+// TamanhoMiniatura é uma miniatura gerada no envio da imagem, identificada
+// pelo nome e limitada pela largura em pixels.
+type TamanhoMiniatura struct {
+	Nome    string
+	Largura int
+}
+
+// TamanhosMiniatura são as miniaturas geradas para toda imagem de produto.
+var TamanhosMiniatura = []TamanhoMiniatura{
+	{Nome: "pequena", Largura: 160},
+	{Nome: "media", Largura: 480},
+	{Nome: "grande", Largura: 1024},
+}
+
+// Imagem é a imagem de um produto. Só Chave é gravada: ela identifica o
+// arquivo original no armazenamento, e as chaves das miniaturas derivam dela.
+// URL e Miniaturas são preenchidas pelo serviço a cada leitura.
+type Imagem struct {
+	Chave      string            `json:"-"`
+	URL        string            `json:"url" example:"/arquivos/produtos/8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a/5c2e9f1a.jpg"`
+	Miniaturas map[string]string `json:"miniaturas" example:"pequena:/arquivos/produtos/8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a/5c2e9f1a_pequena.jpg"`
+}
+
+// ChaveImagem monta a chave do arquivo original de uma imagem enviada para o
+// produto. Cada envio usa um nome novo, para que as URLs possam ser
+// guardadas em cache indefinidamente.
+func ChaveImagem(produtoID, nome, extensao string) string {
+	return path.Join("produtos", produtoID, nome+extensao)
+}
+
+// ChaveMiniatura deriva da chave do original a chave de uma miniatura. As
+// miniaturas de JPEG são JPEG; as demais são PNG, que preserva a
+// transparência.
+func ChaveMiniatura(chave, tamanho string) string {
+	extensao := path.Ext(chave)
+	base := strings.TrimSuffix(chave, extensao)
+	if extensao != ".jpg" {
+		extensao = ".png"
+	}
+	return base + "_" + tamanho + extensao
+}
+
+// Chaves devolve a chave do original seguida das chaves das miniaturas.
+func (i *Imagem) Chaves() []string {
+	chaves := []string{i.Chave}
+	for _, tamanho := range TamanhosMiniatura {
+		chaves = append(chaves, ChaveMiniatura(i.Chave, tamanho.Nome))
+	}
+	return chaves
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	LimitePadrao = 50
+	LimiteMaximo = 200
+)
+
+// Paginacao descreve uma página baseada em cursor (keyset). Cursor é opaco para
+// os clientes e aponta para a última linha da página anterior.
+type Paginacao struct {
+	Limite      int
+	Cursor      string
+	Ordenacao   string
+	Decrescente bool
+}
+
+// Pagina é o resultado de uma listagem paginada. ProximoCursor fica vazio
+// quando não há mais registros.
+type Pagina[T any] struct {
+	Itens         []T
+	ProximoCursor string
+}
+
+// NovaPagina recebe os itens buscados com uma linha a mais que o limite e monta
+// a página, com o cursor apontando para o último item retornado quando há
+// próxima página.
+func NovaPagina[T any](itens []T, p Paginacao, chave func(T) (valor, id string)) *Pagina[T] {
+	pagina := &Pagina[T]{Itens: itens}
+	if pagina.Itens == nil {
+		pagina.Itens = []T{}
+	}
+	if len(itens) > p.Limite {
+		pagina.Itens = itens[:p.Limite]
+		valor, id := chave(pagina.Itens[p.Limite-1])
+		pagina.ProximoCursor = p.NovoCursor(valor, id)
+	}
+	return pagina
+}
+
+// Cursor guarda o valor da coluna de ordenação e o ID da última linha
+// retornada. O ID desempata linhas com o mesmo valor.
+type Cursor struct {
+	Ordenacao string `json:"o"`
+	Valor     string `json:"v"`
+	ID        string `json:"id"`
+}
+
+func CodificarCursor(c Cursor) string {
+	dados, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(dados)
+}
+
+func DecodificarCursor(valor string) (Cursor, error) {
+	var c Cursor
+	dados, err := base64.RawURLEncoding.DecodeString(valor)
+	if err == nil {
+		err = json.Unmarshal(dados, &c)
+	}
+	if err != nil || c.ID == "" {
+		return Cursor{}, NovoErroValidacao("cursor", "INVALIDO", "cursor inválido")
+	}
+	return c, nil
+}
+
+// CursorAtual decodifica o cursor da paginação. Retorna nil na primeira página.
+func (p Paginacao) CursorAtual() (*Cursor, error) {
+	if p.Cursor == "" {
+		return nil, nil
+	}
+	c, err := DecodificarCursor(p.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if c.Ordenacao != p.chave() {
+		return nil, NovoErroValidacao("cursor", "INVALIDO", "cursor não corresponde à ordenação solicitada")
+	}
+	return &c, nil
+}
+
+// NovoCursor monta o cursor que aponta para a linha com o valor e ID informados.
+func (p Paginacao) NovoCursor(valor, id string) string {
+	return CodificarCursor(Cursor{Ordenacao: p.chave(), Valor: valor, ID: id})
+}
+
+func (p Paginacao) chave() string {
+	if p.Decrescente {
+		return "-" + p.Ordenacao
+	}
+	return p.Ordenacao
+}
+
+// normalizar aplica os valores padrão e valida limite e ordenação.
+func (p *Paginacao) normalizar(erros *ErrosValidacao, ordenacaoPadrao string, permitidas ...string) {
+	if p.Limite == 0 {
+		p.Limite = LimitePadrao
+	}
+	if p.Limite < 0 || p.Limite > LimiteMaximo {
+		erros.Adicionar("limit", "FORA_DO_INTERVALO", "limit deve estar entre 1 e "+strconv.Itoa(LimiteMaximo))
+	}
+
+	if p.Ordenacao == "" {
+		p.Ordenacao = ordenacaoPadrao
+	}
+	if !slices.Contains(permitidas, p.Ordenacao) {
+		erros.Adicionar("ordenar", "INVALIDO", "ordenação inválida: "+p.Ordenacao)
+		return
+	}
+
+	if _, err := p.CursorAtual(); err != nil {
+		erros.Adicionar("cursor", "INVALIDO", err.Error())
+	}
+}
+
+func formatarValorOrdenacao(valor any) string {
+	switch v := valor.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return ""
+	}
+}
@@ -1,7 +1,7 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
 	"time"
 )
 
@@ -15,21 +15,22 @@ const (
 )
 
 type ItemPedido struct {
-	ProdutoID  string  `json:"produto_id"`
-	Nome       string  `json:"nome"`
-	Preco      float64 `json:"preco"`
-	Quantidade int     `json:"quantidade"`
-	Observacao string  `json:"observacao,omitempty"`
+	ProdutoID  string  `json:"produto_id" example:"8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a"`
+	Nome       string  `json:"nome" example:"X-Burger"`
+	Preco      float64 `json:"preco" example:"29.9"`
+	Quantidade int     `json:"quantidade" example:"2"`
+	Observacao string  `json:"observacao,omitempty" example:"sem cebola"`
 }
 
 type Pedido struct {
-	ID         string       `json:"id"`
-	ClienteID  *string      `json:"cliente_id,omitempty"`
+	ID         string       `json:"id" example:"5b7e2c1a-9f3d-4e8b-a6c2-1d0f9e8b7a64"`
+	ClienteID  *string      `json:"cliente_id,omitempty" example:"c1552170-1909-443d-bebe-5f71e51ddd17"`
 	Itens      []ItemPedido `json:"itens"`
-	ValorTotal float64      `json:"valor_total"`
-	Status     StatusPedido `json:"status"`
-	CreatedAt  time.Time    `json:"created_at"`
-	UpdatedAt  time.Time    `json:"updated_at"`
+	ValorTotal float64      `json:"valor_total" example:"59.8"`
+	Status     StatusPedido `json:"status" example:"RECEBIDO"`
+	CreatedAt  time.Time    `json:"created_at" example:"2025-01-15T12:30:00Z"`
+	UpdatedAt  time.Time    `json:"updated_at" example:"2025-01-15T12:30:00Z"`
+	Versao     int64        `json:"versao" example:"1"`
 }
 
 func NovoPedido(id string, clienteID *string, itens []ItemPedido) (*Pedido, error) {
@@ -40,6 +41,7 @@ func NovoPedido(id string, clienteID *string, itens []ItemPedido) (*Pedido, erro
 		Status:    StatusRecebido,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Versao:    1,
 	}
 
 	if err := pedido.Validar(); err != nil {
@@ -52,23 +54,29 @@ func NovoPedido(id string, clienteID *string, itens []ItemPedido) (*Pedido, erro
 }
 
 func (p *Pedido) Validar() error {
+	var erros ErrosValidacao
+
 	if len(p.Itens) == 0 {
-		return errors.New("pedido deve ter pelo menos um item")
+		erros.Adicionar("itens", "OBRIGATORIO", "pedido deve ter pelo menos um item")
 	}
 
-	for _, item := range p.Itens {
+	for i, item := range p.Itens {
 		if item.ProdutoID == "" {
-			return errors.New("produto ID não pode ser vazio")
+			erros.Adicionar(CampoItem(i, "produto_id"), "OBRIGATORIO", "produto ID não pode ser vazio")
+		} else if item.Preco <= 0 {
+			erros.Adicionar(CampoItem(i, "preco"), "DEVE_SER_POSITIVO", "preço do item deve ser maior que zero")
 		}
 		if item.Quantidade <= 0 {
-			return errors.New("quantidade deve ser maior que zero")
-		}
-		if item.Preco <= 0 {
-			return errors.New("preço do item deve ser maior que zero")
+			erros.Adicionar(CampoItem(i, "quantidade"), "DEVE_SER_POSITIVO", "quantidade deve ser maior que zero")
 		}
 	}
 
-	return nil
+	return erros.Erro()
+}
+
+// CampoItem monta o nome de um campo de item do pedido, ex.: itens[2].quantidade.
+func CampoItem(indice int, campo string) string {
+	return fmt.Sprintf("itens[%d].%s", indice, campo)
 }
 
 func (p *Pedido) CalcularValorTotal() {
@@ -92,3 +100,54 @@ func IsStatusValido(status StatusPedido) bool {
 		return false
 	}
 }
+
+const (
+	OrdenarPedidosPorCriacao     = "created_at"
+	OrdenarPedidosPorAtualizacao = "updated_at"
+	OrdenarPedidosPorValor       = "valor_total"
+)
+
+// FiltroPedidos são os critérios de listagem de pedidos. Os filtros podem ser
+// combinados; De é inclusivo e Ate é exclusivo e ambos se aplicam a created_at.
+type FiltroPedidos struct {
+	Paginacao
+	Status    []StatusPedido
+	ClienteID string
+	De        *time.Time
+	Ate       *time.Time
+}
+
+// Validar aplica a ordenação padrão: pedidos mais recentes primeiro ou, quando
+// filtrados por status, os mais antigos primeiro, como numa fila de preparo.
+func (f *FiltroPedidos) Validar() error {
+	var erros ErrosValidacao
+
+	if f.Ordenacao == "" {
+		f.Ordenacao = OrdenarPedidosPorCriacao
+		f.Decrescente = len(f.Status) == 0
+	}
+	f.normalizar(&erros, OrdenarPedidosPorCriacao, OrdenarPedidosPorCriacao, OrdenarPedidosPorAtualizacao, OrdenarPedidosPorValor)
+
+	for _, status := range f.Status {
+		if !IsStatusValido(status) {
+			erros.Adicionar("status", "INVALIDO", "status inválido: "+string(status))
+		}
+	}
+	if f.De != nil && f.Ate != nil && !f.De.Before(*f.Ate) {
+		erros.Adicionar("de", "FORA_DO_INTERVALO", "de deve ser anterior a ate")
+	}
+
+	return erros.Erro()
+}
+
+// ValorOrdenacao retorna o valor do campo de ordenação usado para montar o cursor.
+func (p *Pedido) ValorOrdenacao(campo string) string {
+	switch campo {
+	case OrdenarPedidosPorAtualizacao:
+		return formatarValorOrdenacao(p.UpdatedAt)
+	case OrdenarPedidosPorValor:
+		return formatarValorOrdenacao(p.ValorTotal)
+	default:
+		return formatarValorOrdenacao(p.CreatedAt)
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// Situações de um preço no histórico, calculadas em relação a um instante.
+const (
+	PrecoAgendado  = "AGENDADO"
+	PrecoVigente   = "VIGENTE"
+	PrecoEncerrado = "ENCERRADO"
+)
+
+// PrecoProduto é um preço de um produto e o instante a partir do qual vale.
+// O preço vigente é o de maior VigenteDesde que já começou; os de
+// VigenteDesde futuro são alterações agendadas.
+type PrecoProduto struct {
+	ID           int64     `json:"id" example:"7"`
+	ProdutoID    string    `json:"produto_id" example:"8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a"`
+	Preco        float64   `json:"preco" example:"31.9"`
+	VigenteDesde time.Time `json:"vigente_desde" example:"2025-02-01T10:00:00-03:00"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-01-20T18:00:00Z"`
+	// Situacao não é gravada: é preenchida por ClassificarPrecos.
+	Situacao string `json:"situacao,omitempty" example:"VIGENTE" enums:"AGENDADO,VIGENTE,ENCERRADO"`
+}
+
+// NovoPrecoAgendado valida uma alteração de preço que deve entrar em vigor
+// depois de agora.
+func NovoPrecoAgendado(produtoID string, preco float64, vigenteDesde, agora time.Time) (*PrecoProduto, error) {
+	var erros ErrosValidacao
+
+	if preco <= 0 {
+		erros.Adicionar("preco", "DEVE_SER_POSITIVO", "preço deve ser maior que zero")
+	}
+	if !vigenteDesde.After(agora) {
+		erros.Adicionar("vigente_desde", "DEVE_SER_FUTURO", "vigente_desde deve ser um instante futuro")
+	}
+	if err := erros.Erro(); err != nil {
+		return nil, err
+	}
+
+	return &PrecoProduto{
+		ProdutoID:    produtoID,
+		Preco:        preco,
+		VigenteDesde: vigenteDesde,
+		CreatedAt:    agora,
+	}, nil
+}
+
+// ClassificarPrecos preenche a Situacao de cada preço do histórico de um
+// produto em relação a agora. Empates em VigenteDesde são resolvidos pelo
+// maior ID, o registrado por último.
+func ClassificarPrecos(precos []*PrecoProduto, agora time.Time) {
+	var vigente *PrecoProduto
+	for _, preco := range precos {
+		switch {
+		case preco.VigenteDesde.After(agora):
+			preco.Situacao = PrecoAgendado
+		case vigente == nil || preco.VigenteDesde.After(vigente.VigenteDesde) ||
+			(preco.VigenteDesde.Equal(vigente.VigenteDesde) && preco.ID > vigente.ID):
+			if vigente != nil {
+				vigente.Situacao = PrecoEncerrado
+			}
+			preco.Situacao = PrecoVigente
+			vigente = preco
+		default:
+			preco.Situacao = PrecoEncerrado
+		}
+	}
+}
@@ -1,28 +1,24 @@
 package domain
 
 import (
-	"errors"
 	"time"
 )
 
-type Categoria string
-
-const (
-	CategoriaLanche         Categoria = "LANCHE"
-	CategoriaAcompanhamento Categoria = "ACOMPANHAMENTO"
-	CategoriaBebida         Categoria = "BEBIDA"
-	CategoriaSobremesa      Categoria = "SOBREMESA"
-)
-
 type Produto struct {
-	ID         string    `json:"id"`
-	Nome       string    `json:"nome"`
-	Descricao  string    `json:"descricao"`
-	Preco      float64   `json:"preco"`
-	Categoria  Categoria `json:"categoria"`
-	Disponivel bool      `json:"disponivel"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         string    `json:"id" example:"8e0b5f4a-2c1d-4f7e-9a3b-6d2e1f0c9b7a"`
+	Nome       string    `json:"nome" example:"X-Burger"`
+	Descricao  string    `json:"descricao" example:"Pão brioche, hambúrguer 180g e queijo"`
+	Preco      float64   `json:"preco" example:"29.9"`
+	Categoria  Categoria `json:"categoria" example:"LANCHE"`
+	Disponivel bool      `json:"disponivel" example:"true"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-01-15T12:30:00Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2025-01-15T12:30:00Z"`
+	Versao     int64     `json:"versao" example:"1"`
+	// DeletedAt só é preenchido nos produtos removidos, listados pela
+	// administração.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2025-02-01T09:00:00Z" extensions:"x-nullable"`
+	// Imagem fica ausente enquanto nenhuma imagem foi enviada.
+	Imagem *Imagem `json:"imagem,omitempty" extensions:"x-nullable"`
 }
 
 func NovoProduto(id, nome, descricao string, preco float64, categoria Categoria) (*Produto, error) {
@@ -35,6 +31,7 @@ func NovoProduto(id, nome, descricao string, preco float64, categoria Categoria)
 		Disponivel: true,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
+		Versao:     1,
 	}
 
 	if err := produto.Validar(); err != nil {
@@ -45,30 +42,65 @@ func NovoProduto(id, nome, descricao string, preco float64, categoria Categoria)
 }
 
 func (p *Produto) Validar() error {
+	var erros ErrosValidacao
+
 	if p.Nome == "" {
-		return errors.New("nome não pode ser vazio")
+		erros.Adicionar("nome", "OBRIGATORIO", "nome não pode ser vazio")
 	}
 
 	if p.Descricao == "" {
-		return errors.New("descrição não pode ser vazia")
+		erros.Adicionar("descricao", "OBRIGATORIO", "descrição não pode ser vazia")
 	}
 
 	if p.Preco <= 0 {
-		return errors.New("preço deve ser maior que zero")
+		erros.Adicionar("preco", "DEVE_SER_POSITIVO", "preço deve ser maior que zero")
+	}
+
+	// A existência da categoria depende do cadastro e é conferida pelo
+	// serviço.
+	if p.Categoria == "" {
+		erros.Adicionar("categoria", "OBRIGATORIO", "categoria não pode ser vazia")
 	}
 
-	if !IsCategoriaValida(p.Categoria) {
-		return errors.New("categoria inválida")
+	return erros.Erro()
+}
+
+const (
+	OrdenarProdutosPorNome    = "nome"
+	OrdenarProdutosPorPreco   = "preco"
+	OrdenarProdutosPorCriacao = "created_at"
+)
+
+// FiltroProdutos são os critérios de listagem de produtos. Campos vazios ou
+// nil não filtram. Removidos troca os produtos ativos pelos removidos.
+type FiltroProdutos struct {
+	Paginacao
+	Categoria  Categoria
+	PrecoMin   *float64
+	PrecoMax   *float64
+	Disponivel *bool
+	Removidos  bool
+}
+
+func (f *FiltroProdutos) Validar() error {
+	var erros ErrosValidacao
+	f.normalizar(&erros, OrdenarProdutosPorNome, OrdenarProdutosPorNome, OrdenarProdutosPorPreco, OrdenarProdutosPorCriacao)
+
+	if f.PrecoMin != nil && f.PrecoMax != nil && *f.PrecoMin > *f.PrecoMax {
+		erros.Adicionar("preco_min", "FORA_DO_INTERVALO", "preco_min deve ser menor ou igual a preco_max")
 	}
 
-	return nil
+	return erros.Erro()
 }
 
-func IsCategoriaValida(categoria Categoria) bool {
-	switch categoria {
-	case CategoriaLanche, CategoriaAcompanhamento, CategoriaBebida, CategoriaSobremesa:
-		return true
+// ValorOrdenacao retorna o valor do campo de ordenação usado para montar o cursor.
+func (p *Produto) ValorOrdenacao(campo string) string {
+	switch campo {
+	case OrdenarProdutosPorPreco:
+		return formatarValorOrdenacao(p.Preco)
+	case OrdenarProdutosPorCriacao:
+		return formatarValorOrdenacao(p.CreatedAt)
 	default:
-		return false
+		return formatarValorOrdenacao(p.Nome)
 	}
 }
@@ -0,0 +1,16 @@
+package domain
+
+type StatusSaude string
+
+const (
+	SaudeOK    StatusSaude = "UP"
+	SaudeFalha StatusSaude = "DOWN"
+)
+
+// VerificacaoSaude é o resultado da checagem de uma dependência da API.
+// Detalhes traz informações para diagnóstico, como estatísticas do pool.
+type VerificacaoSaude struct {
+	Status   StatusSaude    `json:"status" example:"UP"`
+	Erro     string         `json:"erro,omitempty"`
+	Detalhes map[string]any `json:"detalhes,omitempty"`
+}
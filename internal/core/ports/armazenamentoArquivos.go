@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// ArmazenamentoArquivos guarda arquivos públicos, como as imagens dos
+// produtos, identificados por chaves no formato de caminho relativo
+// ("produtos/<id>/<nome>.jpg").
+type ArmazenamentoArquivos interface {
+	// Salvar grava o conteúdo na chave, substituindo o arquivo anterior.
+	Salvar(ctx context.Context, chave, tipo string, conteudo io.Reader) error
+	// Remover apaga o arquivo; uma chave inexistente não é erro.
+	Remover(ctx context.Context, chave string) error
+	// URL devolve o endereço público do arquivo.
+	URL(chave string) string
+}
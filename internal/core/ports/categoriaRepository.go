@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"soat-fiap/internal/core/domain"
+)
+
+type CategoriaRepository interface {
+	// Criar devolve ErrCategoriaDuplicada quando o código já existe.
+	Criar(ctx context.Context, categoria *domain.CategoriaProduto) error
+	BuscarPorCodigo(ctx context.Context, codigo domain.Categoria) (*domain.CategoriaProduto, error)
+	// Listar ordena por Ordem e, nos empates, pelo código.
+	Listar(ctx context.Context, filtro domain.FiltroCategorias) ([]*domain.CategoriaProduto, error)
+	// Atualizar substitui todos os campos, inclusive as traduções, exceto o
+	// código e CreatedAt, conferindo a versão.
+	Atualizar(ctx context.Context, categoria *domain.CategoriaProduto) error
+	Deletar(ctx context.Context, codigo domain.Categoria) error
+	// EmUso informa se algum produto, mesmo removido, tem a categoria.
+	EmUso(ctx context.Context, codigo domain.Categoria) (bool, error)
+}
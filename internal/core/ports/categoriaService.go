@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"soat-fiap/internal/core/domain"
+)
+
+type CategoriaService interface {
+	CriarCategoria(ctx context.Context, codigo domain.Categoria, nome, icone string, ordem int, traducoes map[string]string) (*domain.CategoriaProduto, error)
+	BuscarCategoria(ctx context.Context, codigo domain.Categoria) (*domain.CategoriaProduto, error)
+	ListarCategorias(ctx context.Context, filtro domain.FiltroCategorias) ([]*domain.CategoriaProduto, error)
+	AtualizarCategoria(ctx context.Context, categoria *domain.CategoriaProduto) error
+	// DeletarCategoria recusa categorias com produtos (ErrCategoriaEmUso).
+	DeletarCategoria(ctx context.Context, codigo domain.Categoria) error
+}
@@ -10,7 +10,12 @@ type ClienteRepository interface {
 	Criar(ctx context.Context, cliente *domain.Cliente) error
 	BuscarPorID(ctx context.Context, id string) (*domain.Cliente, error)
 	BuscarPorCPF(ctx context.Context, cpf string) (*domain.Cliente, error)
-	Listar(ctx context.Context) ([]*domain.Cliente, error)
+	Listar(ctx context.Context, filtro domain.FiltroClientes) (*domain.Pagina[*domain.Cliente], error)
 	Atualizar(ctx context.Context, cliente *domain.Cliente) error
+	// Deletar faz a remoção lógica: o cliente some das buscas e listagens,
+	// mas continua referenciado pelos pedidos antigos.
 	Deletar(ctx context.Context, id string) error
+	// Restaurar desfaz a remoção lógica; ErrClienteNaoEncontrado quando não há
+	// cliente removido com o ID.
+	Restaurar(ctx context.Context, id string) error
 }
@@ -10,7 +10,8 @@ type ClienteService interface {
 	CriarCliente(ctx context.Context, nome, cpf, email, telefone string) (*domain.Cliente, error)
 	BuscarClientePorID(ctx context.Context, id string) (*domain.Cliente, error)
 	BuscarClientePorCPF(ctx context.Context, cpf string) (*domain.Cliente, error)
-	ListarClientes(ctx context.Context) ([]*domain.Cliente, error)
+	ListarClientes(ctx context.Context, filtro domain.FiltroClientes) (*domain.Pagina[*domain.Cliente], error)
 	AtualizarCliente(ctx context.Context, cliente *domain.Cliente) error
 	DeletarCliente(ctx context.Context, id string) error
+	RestaurarCliente(ctx context.Context, id string) (*domain.Cliente, error)
 }
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"time"
+
+	"soat-fiap/internal/core/domain"
+)
+
+// MetricasPedidos recebe os eventos do ciclo de vida dos pedidos para
+// monitoramento. As implementações não devem bloquear nem falhar.
+type MetricasPedidos interface {
+	PedidoCriado(pedido *domain.Pedido)
+	// StatusAlterado informa a transição e quanto tempo o pedido ficou no
+	// status anterior.
+	StatusAlterado(pedido *domain.Pedido, anterior domain.StatusPedido, tempoNoStatus time.Duration)
+}
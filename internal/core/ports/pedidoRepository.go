@@ -8,8 +8,9 @@ import (
 type PedidoRepository interface {
 	Criar(ctx context.Context, pedido *domain.Pedido) error
 	BuscarPorID(ctx context.Context, id string) (*domain.Pedido, error)
-	Listar(ctx context.Context) ([]*domain.Pedido, error)
-	ListarPorStatus(ctx context.Context, status domain.StatusPedido) ([]*domain.Pedido, error)
-	ListarPorCliente(ctx context.Context, clienteID string) ([]*domain.Pedido, error)
+	Listar(ctx context.Context, filtro domain.FiltroPedidos) (*domain.Pagina[*domain.Pedido], error)
 	Atualizar(ctx context.Context, pedido *domain.Pedido) error
+	// ContarPorStatus conta os pedidos gravados em cada status; status sem
+	// pedidos ficam fora do mapa.
+	ContarPorStatus(ctx context.Context) (map[domain.StatusPedido]int, error)
 }
@@ -8,8 +8,6 @@ import (
 type PedidoService interface {
 	CriarPedido(ctx context.Context, clienteID *string, itens []domain.ItemPedido) (*domain.Pedido, error)
 	BuscarPedidoPorID(ctx context.Context, id string) (*domain.Pedido, error)
-	ListarPedidos(ctx context.Context) ([]*domain.Pedido, error)
-	ListarPedidosPorStatus(ctx context.Context, status domain.StatusPedido) ([]*domain.Pedido, error)
-	ListarPedidosPorCliente(ctx context.Context, clienteID string) ([]*domain.Pedido, error)
-	AtualizarStatusPedido(ctx context.Context, id string, status domain.StatusPedido) error
+	ListarPedidos(ctx context.Context, filtro domain.FiltroPedidos) (*domain.Pagina[*domain.Pedido], error)
+	AtualizarStatusPedido(ctx context.Context, id string, status domain.StatusPedido, versao int64) (*domain.Pedido, error)
 }
@@ -3,13 +3,38 @@ package ports
 import (
 	"context"
 	"soat-fiap/internal/core/domain"
+	"time"
 )
 
 type ProdutoRepository interface {
 	Criar(ctx context.Context, produto *domain.Produto) error
 	BuscarPorID(ctx context.Context, id string) (*domain.Produto, error)
-	Listar(ctx context.Context) ([]*domain.Produto, error)
-	ListarPorCategoria(ctx context.Context, categoria domain.Categoria) ([]*domain.Produto, error)
+	Listar(ctx context.Context, filtro domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error)
+	// Atualizar não altera a imagem, que é gravada só por AtualizarImagem.
 	Atualizar(ctx context.Context, produto *domain.Produto) error
+	// AtualizarImagem grava Imagem.Chave (ou a remove, se Imagem for nil) e
+	// UpdatedAt, conferindo a versão como Atualizar.
+	AtualizarImagem(ctx context.Context, produto *domain.Produto) error
+	// Deletar faz a remoção lógica: o produto some das buscas e listagens,
+	// mas continua referenciado pelos itens dos pedidos antigos. Recusa, na
+	// mesma operação que remove, produtos em pedidos ainda não finalizados
+	// (ErrProdutoEmPedidoAberto).
 	Deletar(ctx context.Context, id string) error
+	// Restaurar desfaz a remoção lógica; ErrProdutoNaoEncontrado quando não há
+	// produto removido com o ID.
+	Restaurar(ctx context.Context, id string) error
+
+	// Criar e Atualizar registram no histórico de preços, na mesma
+	// transação, o preço gravado quando ele muda.
+	ListarPrecos(ctx context.Context, produtoID string) ([]*domain.PrecoProduto, error)
+	// PrecoVigente devolve o preço de maior VigenteDesde até em, ou nil se o
+	// produto não tem histórico.
+	PrecoVigente(ctx context.Context, produtoID string, em time.Time) (*domain.PrecoProduto, error)
+	AgendarPreco(ctx context.Context, preco *domain.PrecoProduto) error
+	// CancelarPrecoAgendado remove um preço que ainda não entrou em vigor em
+	// agora; ErrPrecoAgendadoNaoEncontrado caso contrário.
+	CancelarPrecoAgendado(ctx context.Context, produtoID string, id int64, agora time.Time) error
+	// AplicarPrecosVigentes copia para os produtos ativos o preço vigente em
+	// em, quando difere do gravado, e devolve quantos foram alterados.
+	AplicarPrecosVigentes(ctx context.Context, em time.Time) (int64, error)
 }
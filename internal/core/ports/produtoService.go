@@ -3,13 +3,22 @@ package ports
 import (
 	"context"
 	"soat-fiap/internal/core/domain"
+	"time"
 )
 
 type ProdutoService interface {
 	CriarProduto(ctx context.Context, nome, descricao string, preco float64, categoria domain.Categoria) (*domain.Produto, error)
 	BuscarProdutoPorID(ctx context.Context, id string) (*domain.Produto, error)
-	ListarProdutos(ctx context.Context) ([]*domain.Produto, error)
-	ListarProdutosPorCategoria(ctx context.Context, categoria domain.Categoria) ([]*domain.Produto, error)
+	ListarProdutos(ctx context.Context, filtro domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error)
+	BuscarProdutos(ctx context.Context, consulta string, limite int) ([]*domain.Produto, error)
 	AtualizarProduto(ctx context.Context, produto *domain.Produto) error
 	DeletarProduto(ctx context.Context, id string) error
+	RestaurarProduto(ctx context.Context, id string) (*domain.Produto, error)
+	// EnviarImagem substitui a imagem do produto pelo conteúdo enviado. Com
+	// versao diferente de zero, o produto ainda precisa estar nessa versão.
+	EnviarImagem(ctx context.Context, id string, conteudo []byte, versao int64) (*domain.Produto, error)
+	HistoricoPrecos(ctx context.Context, produtoID string) ([]*domain.PrecoProduto, error)
+	AgendarPreco(ctx context.Context, produtoID string, preco float64, vigenteDesde time.Time) (*domain.PrecoProduto, error)
+	CancelarPrecoAgendado(ctx context.Context, produtoID string, id int64) error
+	AplicarPrecosVigentes(ctx context.Context) error
 }
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"soat-fiap/internal/core/domain"
+)
+
+// VerificadorSaude checa uma dependência que precisa estar disponível para a
+// API receber tráfego. Verificar deve respeitar o prazo do contexto.
+type VerificadorSaude interface {
+	Nome() string
+	Verificar(ctx context.Context) domain.VerificacaoSaude
+}
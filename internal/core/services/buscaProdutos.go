@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/pkg/logger"
+)
+
+const (
+	// validadeIndice limita por quanto tempo o índice é reaproveitado antes de
+	// ser reconstruído a partir do repositório, para refletir alterações feitas
+	// por outras réplicas da API.
+	validadeIndice = 5 * time.Minute
+
+	pesoNome        = 2.0
+	pesoDescricao   = 1.0
+	bonusExato      = 3.0
+	bonusPrefixo    = 2.0
+	bonusSimilar    = 1.0
+	bonusDisponivel = 1.5
+)
+
+// documentoBusca é um produto com o texto já normalizado em termos.
+type documentoBusca struct {
+	produto         *domain.Produto
+	termosNome      []string
+	termosDescricao []string
+}
+
+// indiceProdutos é um índice de busca em memória. O cardápio tem poucas
+// centenas de itens, então a busca percorre todos os documentos em vez de
+// manter um índice invertido.
+type indiceProdutos struct {
+	mu           sync.RWMutex
+	documentos   map[string]documentoBusca
+	construidoEm time.Time
+	// construcao é a reconstrução em andamento, compartilhada pelas buscas
+	// que chegam enquanto ela roda; nil quando não há nenhuma.
+	construcao *construcaoIndice
+}
+
+// construcaoIndice acompanha uma reconstrução. As alterações feitas enquanto
+// os produtos são lidos do repositório ficam em pendentes (nil indica
+// remoção) e são aplicadas depois da troca, para que não se percam.
+type construcaoIndice struct {
+	pronta     chan struct{}
+	err        error
+	pendentes  map[string]*documentoBusca
+	invalidada bool
+}
+
+func novoIndiceProdutos() *indiceProdutos {
+	return &indiceProdutos{}
+}
+
+// garantir reconstrói o índice quando ele ainda não existe ou expirou. Só
+// uma reconstrução roda por vez: as demais chamadas aguardam o resultado
+// dela.
+func (i *indiceProdutos) garantir(ctx context.Context, listar func(context.Context, domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error)) error {
+	i.mu.Lock()
+	if i.documentos != nil && time.Since(i.construidoEm) < validadeIndice {
+		i.mu.Unlock()
+		return nil
+	}
+	if c := i.construcao; c != nil {
+		i.mu.Unlock()
+		select {
+		case <-c.pronta:
+			return c.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c := &construcaoIndice{pronta: make(chan struct{}), pendentes: make(map[string]*documentoBusca)}
+	i.construcao = c
+	i.mu.Unlock()
+
+	// A leitura não é cancelada com a requisição que a iniciou, já que outras
+	// buscas podem estar aguardando o mesmo resultado.
+	documentos, err := carregarDocumentos(context.WithoutCancel(ctx), listar)
+
+	i.mu.Lock()
+	if err == nil {
+		for id, doc := range c.pendentes {
+			if doc == nil {
+				delete(documentos, id)
+			} else {
+				documentos[id] = *doc
+			}
+		}
+		i.documentos = documentos
+		i.construidoEm = time.Now()
+		if c.invalidada {
+			i.construidoEm = time.Time{}
+		}
+	}
+	i.construcao = nil
+	i.mu.Unlock()
+
+	c.err = err
+	close(c.pronta)
+
+	if err == nil {
+		logger.DoContexto(ctx).Debug("índice de busca reconstruído", "produtos", len(documentos))
+	}
+	return err
+}
+
+// carregarDocumentos lê todos os produtos do repositório, página a página.
+func carregarDocumentos(ctx context.Context, listar func(context.Context, domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error)) (map[string]documentoBusca, error) {
+	documentos := make(map[string]documentoBusca)
+
+	filtro := domain.FiltroProdutos{Paginacao: domain.Paginacao{Limite: domain.LimiteMaximo}}
+	if err := filtro.Validar(); err != nil {
+		return nil, err
+	}
+	for {
+		pagina, err := listar(ctx, filtro)
+		if err != nil {
+			return nil, err
+		}
+		for _, produto := range pagina.Itens {
+			documentos[produto.ID] = novoDocumentoBusca(produto)
+		}
+		if pagina.ProximoCursor == "" {
+			return documentos, nil
+		}
+		filtro.Cursor = pagina.ProximoCursor
+	}
+}
+
+// atualizar inclui ou substitui um produto. Enquanto o índice não foi
+// construído não há o que atualizar: a primeira busca carrega tudo.
+func (i *indiceProdutos) atualizar(produto *domain.Produto) {
+	doc := novoDocumentoBusca(produto)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.documentos != nil {
+		i.documentos[produto.ID] = doc
+	}
+	if i.construcao != nil {
+		i.construcao.pendentes[produto.ID] = &doc
+	}
+}
+
+func (i *indiceProdutos) remover(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.documentos, id)
+	if i.construcao != nil {
+		i.construcao.pendentes[id] = nil
+	}
+}
+
+// invalidar descarta o índice para que a próxima busca o reconstrua, quando
+// muitos produtos mudaram de uma vez. Uma reconstrução em andamento pode ter
+// lido os produtos antes da mudança, então o resultado dela já nasce
+// expirado.
+func (i *indiceProdutos) invalidar() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.documentos = nil
+	if i.construcao != nil {
+		i.construcao.invalidada = true
+	}
+}
+
+type resultadoBusca struct {
+	produto    *domain.Produto
+	relevancia float64
+}
+
+// buscar devolve os produtos com algum termo parecido com a consulta,
+// ordenados por relevância. Produtos disponíveis recebem um bônus.
+func (i *indiceProdutos) buscar(consulta string, limite int) []*domain.Produto {
+	termosConsulta := normalizarTermos(consulta)
+
+	i.mu.RLock()
+	var resultados []resultadoBusca
+	for _, doc := range i.documentos {
+		relevancia := 0.0
+		for _, termo := range termosConsulta {
+			relevancia += pesoNome*pontuarTermo(termo, doc.termosNome) +
+				pesoDescricao*pontuarTermo(termo, doc.termosDescricao)
+		}
+		if relevancia == 0 {
+			continue
+		}
+		if doc.produto.Disponivel {
+			relevancia *= bonusDisponivel
+		}
+		resultados = append(resultados, resultadoBusca{produto: doc.produto, relevancia: relevancia})
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(resultados, func(a, b int) bool {
+		if resultados[a].relevancia != resultados[b].relevancia {
+			return resultados[a].relevancia > resultados[b].relevancia
+		}
+		return resultados[a].produto.Nome < resultados[b].produto.Nome
+	})
+
+	produtos := make([]*domain.Produto, 0, min(limite, len(resultados)))
+	for _, resultado := range resultados {
+		if len(produtos) == limite {
+			break
+		}
+		produtos = append(produtos, resultado.produto)
+	}
+	return produtos
+}
+
+func novoDocumentoBusca(produto *domain.Produto) documentoBusca {
+	copia := *produto
+	return documentoBusca{
+		produto:         &copia,
+		termosNome:      normalizarTermos(produto.Nome),
+		termosDescricao: normalizarTermos(produto.Descricao),
+	}
+}
+
+// pontuarTermo retorna a melhor correspondência do termo entre os termos do
+// documento: igual, prefixo ou com poucos erros de digitação.
+func pontuarTermo(termo string, termosDocumento []string) float64 {
+	melhor := 0.0
+	for _, candidato := range termosDocumento {
+		switch {
+		case candidato == termo:
+			return bonusExato
+		case strings.HasPrefix(candidato, termo):
+			melhor = max(melhor, bonusPrefixo)
+		case distanciaEdicao(termo, candidato) <= tolerancia(termo):
+			melhor = max(melhor, bonusSimilar)
+		}
+	}
+	return melhor
+}
+
+// tolerancia é a quantidade de erros de digitação aceita conforme o tamanho
+// do termo. Termos curtos precisam ser exatos ou prefixos.
+func tolerancia(termo string) int {
+	switch n := len([]rune(termo)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// distanciaEdicao calcula a distância de Levenshtein entre dois termos.
+func distanciaEdicao(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	anterior := make([]int, len(rb)+1)
+	atual := make([]int, len(rb)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		atual[0] = i
+		for j := 1; j <= len(rb); j++ {
+			custo := 1
+			if ra[i-1] == rb[j-1] {
+				custo = 0
+			}
+			atual[j] = min(anterior[j]+1, atual[j-1]+1, anterior[j-1]+custo)
+		}
+		anterior, atual = atual, anterior
+	}
+	return anterior[len(rb)]
+}
+
+var semAcento = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// normalizarTermos separa o texto em termos minúsculos e sem acento.
+func normalizarTermos(texto string) []string {
+	texto = semAcento.Replace(strings.ToLower(texto))
+	return strings.FieldsFunc(texto, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
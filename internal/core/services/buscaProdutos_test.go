@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"soat-fiap/internal/core/domain"
+)
+
+// listagemBloqueada simula um repositório lento: cada chamada de listar
+// avisa em iniciada e espera liberar antes de devolver os produtos.
+type listagemBloqueada struct {
+	produtos   []*domain.Produto
+	chamadas   atomic.Int32
+	iniciada   chan struct{}
+	liberar    chan struct{}
+	avisoUnico sync.Once
+}
+
+func novaListagemBloqueada(produtos ...*domain.Produto) *listagemBloqueada {
+	return &listagemBloqueada{
+		produtos: produtos,
+		iniciada: make(chan struct{}),
+		liberar:  make(chan struct{}),
+	}
+}
+
+func (l *listagemBloqueada) listar(ctx context.Context, _ domain.FiltroProdutos) (*domain.Pagina[*domain.Produto], error) {
+	l.chamadas.Add(1)
+	l.avisoUnico.Do(func() { close(l.iniciada) })
+	<-l.liberar
+	return &domain.Pagina[*domain.Produto]{Itens: l.produtos}, nil
+}
+
+func nomesEncontrados(indice *indiceProdutos, consulta string) map[string]bool {
+	nomes := make(map[string]bool)
+	for _, produto := range indice.buscar(consulta, LimiteBuscaMaximo) {
+		nomes[produto.Nome] = true
+	}
+	return nomes
+}
+
+// Buscas simultâneas com o índice expirado compartilham uma só reconstrução,
+// e as alterações feitas enquanto ela lê o repositório não se perdem.
+func TestIndiceReconstrucaoConcorrente(t *testing.T) {
+	burger := &domain.Produto{ID: "p-1", Nome: "Burger Classico", Disponivel: true}
+	suco := &domain.Produto{ID: "p-2", Nome: "Suco de Laranja", Disponivel: true}
+	listagem := novaListagemBloqueada(burger, suco)
+	indice := novoIndiceProdutos()
+
+	var buscas sync.WaitGroup
+	erros := make(chan error, 10)
+	for range 10 {
+		buscas.Add(1)
+		go func() {
+			defer buscas.Done()
+			erros <- indice.garantir(context.Background(), listagem.listar)
+		}()
+	}
+
+	// Com a leitura em andamento: um produto novo, um alterado e um removido.
+	<-listagem.iniciada
+	indice.atualizar(&domain.Produto{ID: "p-3", Nome: "Burger Duplo", Disponivel: true})
+	indice.atualizar(&domain.Produto{ID: "p-1", Nome: "Burger Especial", Disponivel: true})
+	indice.remover("p-2")
+	close(listagem.liberar)
+
+	buscas.Wait()
+	close(erros)
+	for err := range erros {
+		if err != nil {
+			t.Fatalf("garantir: %v", err)
+		}
+	}
+
+	if chamadas := listagem.chamadas.Load(); chamadas != 1 {
+		t.Errorf("esperada 1 reconstrução, obtidas %d", chamadas)
+	}
+	encontrados := nomesEncontrados(indice, "burger")
+	if !encontrados["Burger Duplo"] || !encontrados["Burger Especial"] || encontrados["Burger Classico"] {
+		t.Errorf("alterações feitas durante a reconstrução foram perdidas: %v", encontrados)
+	}
+	if encontrados := nomesEncontrados(indice, "suco"); len(encontrados) != 0 {
+		t.Errorf("produto removido durante a reconstrução continua no índice: %v", encontrados)
+	}
+}
+
+// Invalidar durante a reconstrução faz a próxima busca reconstruir de novo,
+// já que a leitura em andamento pode ser anterior à mudança.
+func TestIndiceInvalidadoDuranteReconstrucao(t *testing.T) {
+	listagem := novaListagemBloqueada(&domain.Produto{ID: "p-1", Nome: "Burger"})
+	indice := novoIndiceProdutos()
+
+	feito := make(chan error)
+	go func() { feito <- indice.garantir(context.Background(), listagem.listar) }()
+	<-listagem.iniciada
+	indice.invalidar()
+	close(listagem.liberar)
+	if err := <-feito; err != nil {
+		t.Fatalf("garantir: %v", err)
+	}
+
+	if err := indice.garantir(context.Background(), listagem.listar); err != nil {
+		t.Fatalf("garantir: %v", err)
+	}
+	if chamadas := listagem.chamadas.Load(); chamadas != 2 {
+		t.Errorf("esperadas 2 reconstruções, obtidas %d", chamadas)
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/internal/core/ports"
+	"soat-fiap/pkg/logger"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CategoriaService struct {
+	repository ports.CategoriaRepository
+}
+
+func NovoCategoriaService(repository ports.CategoriaRepository) *CategoriaService {
+	return &CategoriaService{
+		repository: repository,
+	}
+}
+
+func (s *CategoriaService) CriarCategoria(ctx context.Context, codigo domain.Categoria, nome, icone string, ordem int, traducoes map[string]string) (_ *domain.CategoriaProduto, err error) {
+	ctx, span := iniciarSpan(ctx, "CategoriaService.CriarCategoria", attribute.String("categoria.codigo", string(codigo)))
+	defer func() { finalizarSpan(span, err) }()
+
+	categoria, err := domain.NovaCategoriaProduto(codigo, nome, icone, ordem, traducoes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Criar(ctx, categoria); err != nil {
+		return nil, err
+	}
+
+	logger.DoContexto(ctx).Info("categoria cadastrada", "categoria", categoria.Codigo)
+	return categoria, nil
+}
+
+func (s *CategoriaService) BuscarCategoria(ctx context.Context, codigo domain.Categoria) (_ *domain.CategoriaProduto, err error) {
+	ctx, span := iniciarSpan(ctx, "CategoriaService.BuscarCategoria", attribute.String("categoria.codigo", string(codigo)))
+	defer func() { finalizarSpan(span, err) }()
+
+	categoria, err := s.repository.BuscarPorCodigo(ctx, codigo)
+	if err != nil {
+		return nil, err
+	}
+	if categoria == nil {
+		return nil, domain.ErrCategoriaNaoEncontrada
+	}
+	return categoria, nil
+}
+
+func (s *CategoriaService) ListarCategorias(ctx context.Context, filtro domain.FiltroCategorias) (_ []*domain.CategoriaProduto, err error) {
+	ctx, span := iniciarSpan(ctx, "CategoriaService.ListarCategorias")
+	defer func() { finalizarSpan(span, err) }()
+
+	return s.repository.Listar(ctx, filtro)
+}
+
+// AtualizarCategoria grava as alterações somente se categoria.Versao ainda
+// for a versão atual; caso contrário devolve ErrVersaoDesatualizada. Desativar
+// uma categoria não altera os produtos que já estão nela.
+func (s *CategoriaService) AtualizarCategoria(ctx context.Context, categoria *domain.CategoriaProduto) (err error) {
+	ctx, span := iniciarSpan(ctx, "CategoriaService.AtualizarCategoria", attribute.String("categoria.codigo", string(categoria.Codigo)))
+	defer func() { finalizarSpan(span, err) }()
+
+	existente, err := s.repository.BuscarPorCodigo(ctx, categoria.Codigo)
+	if err != nil {
+		return err
+	}
+	if existente == nil {
+		return domain.ErrCategoriaNaoEncontrada
+	}
+	if existente.Versao != categoria.Versao {
+		return domain.ErrVersaoDesatualizada
+	}
+
+	if err := categoria.Validar(); err != nil {
+		return err
+	}
+
+	categoria.UpdatedAt = time.Now()
+
+	if err := s.repository.Atualizar(ctx, categoria); err != nil {
+		return err
+	}
+
+	logger.DoContexto(ctx).Info("categoria atualizada", "categoria", categoria.Codigo, "ativa", categoria.Ativa)
+	return nil
+}
+
+// DeletarCategoria remove uma categoria sem produtos. Categorias com
+// produtos, mesmo que removidos, resultam em ErrCategoriaEmUso: o caminho é
+// desativá-las.
+func (s *CategoriaService) DeletarCategoria(ctx context.Context, codigo domain.Categoria) (err error) {
+	ctx, span := iniciarSpan(ctx, "CategoriaService.DeletarCategoria", attribute.String("categoria.codigo", string(codigo)))
+	defer func() { finalizarSpan(span, err) }()
+
+	emUso, err := s.repository.EmUso(ctx, codigo)
+	if err != nil {
+		return err
+	}
+	if emUso {
+		return domain.ErrCategoriaEmUso
+	}
+
+	if err := s.repository.Deletar(ctx, codigo); err != nil {
+		return err
+	}
+
+	logger.DoContexto(ctx).Info("categoria removida", "categoria", codigo)
+	return nil
+}
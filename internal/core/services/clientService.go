@@ -5,9 +5,11 @@ import (
 	"errors"
 	"soat-fiap/internal/core/domain"
 	"soat-fiap/internal/core/ports"
+	"soat-fiap/pkg/logger"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type ClienteService struct {
@@ -20,11 +22,17 @@ func NovoClienteService(repository ports.ClienteRepository) *ClienteService {
 	}
 }
 
-func (s *ClienteService) CriarCliente(ctx context.Context, nome, cpf, email, telefone string) (*domain.Cliente, error) {
+func (s *ClienteService) CriarCliente(ctx context.Context, nome, cpf, email, telefone string) (_ *domain.Cliente, err error) {
+	ctx, span := iniciarSpan(ctx, "ClienteService.CriarCliente")
+	defer func() { finalizarSpan(span, err) }()
 
 	clienteExistente, err := s.repository.BuscarPorCPF(ctx, cpf)
-	if err == nil && clienteExistente != nil {
-		return nil, errors.New("já existe um cliente com este CPF")
+	if err != nil {
+		return nil, err
+	}
+	if clienteExistente != nil {
+		logger.DoContexto(ctx).Info("cadastro recusado: CPF já cadastrado", "cpf", cpf)
+		return nil, domain.ErrCPFDuplicado
 	}
 
 	id := uuid.New().String()
@@ -39,37 +47,76 @@ func (s *ClienteService) CriarCliente(ctx context.Context, nome, cpf, email, tel
 		return nil, err
 	}
 
+	logger.DoContexto(ctx).Info("cliente cadastrado", "cliente_id", cliente.ID, "cpf", cliente.CPF, "email", cliente.Email)
 	return cliente, nil
 }
 
-func (s *ClienteService) BuscarClientePorID(ctx context.Context, id string) (*domain.Cliente, error) {
-	return s.repository.BuscarPorID(ctx, id)
+func (s *ClienteService) BuscarClientePorID(ctx context.Context, id string) (_ *domain.Cliente, err error) {
+	ctx, span := iniciarSpan(ctx, "ClienteService.BuscarClientePorID", attribute.String("cliente.id", id))
+	defer func() { finalizarSpan(span, err) }()
+
+	cliente, err := s.repository.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cliente == nil {
+		return nil, domain.ErrClienteNaoEncontrado
+	}
+	return cliente, nil
 }
 
-func (s *ClienteService) BuscarClientePorCPF(ctx context.Context, cpf string) (*domain.Cliente, error) {
+func (s *ClienteService) BuscarClientePorCPF(ctx context.Context, cpf string) (_ *domain.Cliente, err error) {
+	ctx, span := iniciarSpan(ctx, "ClienteService.BuscarClientePorCPF")
+	defer func() { finalizarSpan(span, err) }()
+
 	if !domain.ValidarCPF(cpf) {
-		return nil, errors.New("CPF inválido")
+		return nil, domain.NovoErroValidacao("cpf", "INVALIDO", "CPF inválido")
 	}
-	return s.repository.BuscarPorCPF(ctx, cpf)
+	cliente, err := s.repository.BuscarPorCPF(ctx, cpf)
+	if err != nil {
+		return nil, err
+	}
+	if cliente == nil {
+		return nil, domain.ErrClienteNaoEncontrado
+	}
+	return cliente, nil
 }
 
-func (s *ClienteService) ListarClientes(ctx context.Context) ([]*domain.Cliente, error) {
-	return s.repository.Listar(ctx)
+func (s *ClienteService) ListarClientes(ctx context.Context, filtro domain.FiltroClientes) (_ *domain.Pagina[*domain.Cliente], err error) {
+	ctx, span := iniciarSpan(ctx, "ClienteService.ListarClientes")
+	defer func() { finalizarSpan(span, err) }()
+
+	if err := filtro.Validar(); err != nil {
+		return nil, err
+	}
+	return s.repository.Listar(ctx, filtro)
 }
 
-func (s *ClienteService) AtualizarCliente(ctx context.Context, cliente *domain.Cliente) error {
+// AtualizarCliente grava as alterações somente se cliente.Versao ainda for a versão
+// atual; caso contrário devolve ErrVersaoDesatualizada. Em caso de sucesso a
+// versão é incrementada.
+func (s *ClienteService) AtualizarCliente(ctx context.Context, cliente *domain.Cliente) (err error) {
+	ctx, span := iniciarSpan(ctx, "ClienteService.AtualizarCliente", attribute.String("cliente.id", cliente.ID))
+	defer func() { finalizarSpan(span, err) }()
+
 	clienteExistente, err := s.repository.BuscarPorID(ctx, cliente.ID)
 	if err != nil {
 		return err
 	}
 	if clienteExistente == nil {
-		return errors.New("cliente não encontrado")
+		return domain.ErrClienteNaoEncontrado
+	}
+	if clienteExistente.Versao != cliente.Versao {
+		return domain.ErrVersaoDesatualizada
 	}
 
 	if clienteExistente.CPF != cliente.CPF {
 		clienteComMesmoCPF, err := s.repository.BuscarPorCPF(ctx, cliente.CPF)
-		if err == nil && clienteComMesmoCPF != nil && clienteComMesmoCPF.ID != cliente.ID {
-			return errors.New("já existe outro cliente com este CPF")
+		if err != nil {
+			return err
+		}
+		if clienteComMesmoCPF != nil && clienteComMesmoCPF.ID != cliente.ID {
+			return domain.ErrCPFDuplicado
 		}
 	}
 
@@ -80,9 +127,49 @@ func (s *ClienteService) AtualizarCliente(ctx context.Context, cliente *domain.C
 
 	cliente.UpdatedAt = time.Now()
 
-	return s.repository.Atualizar(ctx, cliente)
+	if err := s.repository.Atualizar(ctx, cliente); err != nil {
+		return err
+	}
+
+	logger.DoContexto(ctx).Info("cliente atualizado", "cliente_id", cliente.ID)
+	return nil
+}
+
+func (s *ClienteService) DeletarCliente(ctx context.Context, id string) (err error) {
+	ctx, span := iniciarSpan(ctx, "ClienteService.DeletarCliente", attribute.String("cliente.id", id))
+	defer func() { finalizarSpan(span, err) }()
+
+	if err := s.repository.Deletar(ctx, id); err != nil {
+		return err
+	}
+
+	logger.DoContexto(ctx).Info("cliente removido", "cliente_id", id)
+	return nil
 }
 
-func (s *ClienteService) DeletarCliente(ctx context.Context, id string) error {
-	return s.repository.Deletar(ctx, id)
+// RestaurarCliente desfaz a remoção lógica de um cliente. Um cliente ativo
+// resulta em ErrClienteNaoRemovido.
+func (s *ClienteService) RestaurarCliente(ctx context.Context, id string) (_ *domain.Cliente, err error) {
+	ctx, span := iniciarSpan(ctx, "ClienteService.RestaurarCliente", attribute.String("cliente.id", id))
+	defer func() { finalizarSpan(span, err) }()
+
+	if err := s.repository.Restaurar(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrClienteNaoEncontrado) {
+			if ativo, errBusca := s.repository.BuscarPorID(ctx, id); errBusca == nil && ativo != nil {
+				return nil, domain.ErrClienteNaoRemovido
+			}
+		}
+		return nil, err
+	}
+
+	cliente, err := s.repository.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cliente == nil {
+		return nil, domain.ErrClienteNaoEncontrado
+	}
+
+	logger.DoContexto(ctx).Info("cliente restaurado", "cliente_id", id)
+	return cliente, nil
 }
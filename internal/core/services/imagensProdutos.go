@@ -0,0 +1,137 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"path"
+	"time"
+
+	"soat-fiap/internal/core/domain"
+	"soat-fiap/pkg/imagem"
+	"soat-fiap/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PixelsMaximosImagem limita as dimensões das imagens enviadas ao
+// equivalente a 4096 x 4096. O limite é conferido antes de decodificar, pois
+// a imagem decodificada ocupa 4 bytes por pixel na memória.
+const PixelsMaximosImagem = 4096 * 4096
+
+// EnviarImagem valida o conteúdo, grava o original e as miniaturas e associa
+// a imagem ao produto, substituindo a anterior. Com versao diferente de zero,
+// o produto ainda precisa estar nessa versão.
+func (s *ProdutoService) EnviarImagem(ctx context.Context, id string, conteudo []byte, versao int64) (_ *domain.Produto, err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.EnviarImagem", attribute.String("produto.id", id), attribute.Int("imagem.bytes", len(conteudo)))
+	defer func() { finalizarSpan(span, err) }()
+
+	produto, err := s.repository.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if produto == nil {
+		return nil, domain.ErrProdutoNaoEncontrado
+	}
+	if versao != 0 && produto.Versao != versao {
+		return nil, domain.ErrVersaoDesatualizada
+	}
+
+	tipo, extensao, err := imagem.Detectar(conteudo)
+	if err != nil {
+		return nil, domain.NovoErroValidacao("imagem", "FORMATO_NAO_SUPORTADO", "a imagem deve ser JPEG, PNG ou GIF")
+	}
+	original, err := imagem.Decodificar(conteudo, PixelsMaximosImagem)
+	if errors.Is(err, imagem.ErrDimensoesExcedidas) {
+		return nil, domain.NovoErroValidacao("imagem", "DIMENSOES_EXCEDIDAS", "a imagem deve ter no máximo 4096 x 4096 pixels")
+	}
+	if err != nil {
+		return nil, domain.NovoErroValidacao("imagem", "INVALIDO", "a imagem está corrompida ou não pôde ser lida")
+	}
+
+	nova := &domain.Imagem{Chave: domain.ChaveImagem(id, uuid.New().String(), extensao)}
+	if err := s.gravarImagem(ctx, nova, tipo, conteudo, original); err != nil {
+		s.removerArquivos(ctx, nova)
+		return nil, err
+	}
+
+	anterior := produto.Imagem
+	produto.Imagem = nova
+	produto.UpdatedAt = time.Now()
+	if err := s.repository.AtualizarImagem(ctx, produto); err != nil {
+		s.removerArquivos(ctx, nova)
+		return nil, err
+	}
+	if anterior != nil {
+		s.removerArquivos(ctx, anterior)
+	}
+
+	s.preencherURLs(produto)
+	s.indice.atualizar(produto)
+	logger.DoContexto(ctx).Info("imagem do produto enviada", "produto_id", id, "tipo", tipo, "bytes", len(conteudo))
+	return produto, nil
+}
+
+// gravarImagem salva o arquivo enviado sem alterações e uma miniatura para
+// cada domain.TamanhosMiniatura.
+func (s *ProdutoService) gravarImagem(ctx context.Context, img *domain.Imagem, tipo string, conteudo []byte, original image.Image) error {
+	if err := s.armazenamento.Salvar(ctx, img.Chave, tipo, bytes.NewReader(conteudo)); err != nil {
+		return err
+	}
+
+	larguras := make([]int, len(domain.TamanhosMiniatura))
+	for i, tamanho := range domain.TamanhosMiniatura {
+		larguras[i] = tamanho.Largura
+	}
+	for i, miniatura := range imagem.Reduzir(original, larguras...) {
+		chave := domain.ChaveMiniatura(img.Chave, domain.TamanhosMiniatura[i].Nome)
+		extensao := path.Ext(chave)
+		tipoMiniatura := "image/png"
+		if extensao == ".jpg" {
+			tipoMiniatura = "image/jpeg"
+		}
+
+		var codificada bytes.Buffer
+		if err := imagem.Codificar(&codificada, miniatura, extensao); err != nil {
+			return err
+		}
+		if err := s.armazenamento.Salvar(ctx, chave, tipoMiniatura, &codificada); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removerArquivos apaga o original e as miniaturas. Falhas só são
+// registradas: um arquivo que sobrou não afeta o produto.
+func (s *ProdutoService) removerArquivos(ctx context.Context, img *domain.Imagem) {
+	for _, chave := range img.Chaves() {
+		if err := s.armazenamento.Remover(ctx, chave); err != nil {
+			logger.DoContexto(ctx).Warn("não foi possível remover o arquivo da imagem", "chave", chave, "erro", err)
+		}
+	}
+}
+
+// preencherURLs resolve as URLs da imagem a partir da chave gravada. A Imagem
+// é substituída, e não alterada, porque o valor lido pode ser compartilhado
+// com o repositório em memória e com o índice de busca.
+func (s *ProdutoService) preencherURLs(produtos ...*domain.Produto) {
+	for _, produto := range produtos {
+		if produto.Imagem == nil {
+			continue
+		}
+
+		chave := produto.Imagem.Chave
+		resolvida := &domain.Imagem{
+			Chave:      chave,
+			URL:        s.armazenamento.URL(chave),
+			Miniaturas: make(map[string]string, len(domain.TamanhosMiniatura)),
+		}
+		for _, tamanho := range domain.TamanhosMiniatura {
+			resolvida.Miniaturas[tamanho.Nome] = s.armazenamento.URL(domain.ChaveMiniatura(chave, tamanho.Nome))
+		}
+		produto.Imagem = resolvida
+	}
+}
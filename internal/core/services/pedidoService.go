@@ -2,41 +2,69 @@ package services
 
 import (
 	"context"
-	"errors"
 	"soat-fiap/internal/core/domain"
 	"soat-fiap/internal/core/ports"
+	"soat-fiap/pkg/logger"
+	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type PedidoService struct {
 	pedidoRepository  ports.PedidoRepository
 	produtoRepository ports.ProdutoRepository
+	metricas          ports.MetricasPedidos
 }
 
-func NovoPedidoService(pedidoRepository ports.PedidoRepository, produtoRepository ports.ProdutoRepository) *PedidoService {
+func NovoPedidoService(pedidoRepository ports.PedidoRepository, produtoRepository ports.ProdutoRepository, metricas ports.MetricasPedidos) *PedidoService {
 	return &PedidoService{
 		pedidoRepository:  pedidoRepository,
 		produtoRepository: produtoRepository,
+		metricas:          metricas,
 	}
 }
 
-func (s *PedidoService) CriarPedido(ctx context.Context, clienteID *string, itens []domain.ItemPedido) (*domain.Pedido, error) {
+func (s *PedidoService) CriarPedido(ctx context.Context, clienteID *string, itens []domain.ItemPedido) (_ *domain.Pedido, err error) {
+	ctx, span := iniciarSpan(ctx, "PedidoService.CriarPedido", attribute.Int("pedido.itens", len(itens)))
+	defer func() { finalizarSpan(span, err) }()
 
+	// Todos os itens usam os preços vigentes no mesmo instante, para que uma
+	// alteração agendada não valha só para parte do pedido.
+	agora := time.Now()
+	var erros domain.ErrosValidacao
 	for i, item := range itens {
+		if item.ProdutoID == "" {
+			continue
+		}
+
 		produto, err := s.produtoRepository.BuscarPorID(ctx, item.ProdutoID)
 		if err != nil {
 			return nil, err
 		}
 		if produto == nil {
-			return nil, errors.New("produto não encontrado: " + item.ProdutoID)
+			erros.Adicionar(domain.CampoItem(i, "produto_id"), "PRODUTO_NAO_ENCONTRADO", "produto não encontrado: "+item.ProdutoID)
+			continue
 		}
 		if !produto.Disponivel {
-			return nil, errors.New("produto não disponível: " + produto.Nome)
+			erros.Adicionar(domain.CampoItem(i, "produto_id"), "PRODUTO_INDISPONIVEL", "produto não disponível: "+produto.Nome)
+			continue
+		}
+
+		// Produtos sem histórico de preços usam o preço do cadastro.
+		preco, err := s.produtoRepository.PrecoVigente(ctx, produto.ID, agora)
+		if err != nil {
+			return nil, err
 		}
 
 		itens[i].Nome = produto.Nome
 		itens[i].Preco = produto.Preco
+		if preco != nil {
+			itens[i].Preco = preco.Preco
+		}
+	}
+	if err := erros.Erro(); err != nil {
+		return nil, err
 	}
 
 	id := uuid.New().String()
@@ -50,41 +78,73 @@ func (s *PedidoService) CriarPedido(ctx context.Context, clienteID *string, iten
 		return nil, err
 	}
 
+	span.SetAttributes(attribute.String("pedido.id", pedido.ID))
+	s.metricas.PedidoCriado(pedido)
+	logger.DoContexto(ctx).Info("pedido criado",
+		"pedido_id", pedido.ID,
+		"itens", len(pedido.Itens),
+		"valor_total", pedido.ValorTotal,
+	)
+
 	return pedido, nil
 }
 
-func (s *PedidoService) BuscarPedidoPorID(ctx context.Context, id string) (*domain.Pedido, error) {
-	return s.pedidoRepository.BuscarPorID(ctx, id)
-}
+func (s *PedidoService) BuscarPedidoPorID(ctx context.Context, id string) (_ *domain.Pedido, err error) {
+	ctx, span := iniciarSpan(ctx, "PedidoService.BuscarPedidoPorID", attribute.String("pedido.id", id))
+	defer func() { finalizarSpan(span, err) }()
 
-func (s *PedidoService) ListarPedidos(ctx context.Context) ([]*domain.Pedido, error) {
-	return s.pedidoRepository.Listar(ctx)
+	pedido, err := s.pedidoRepository.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pedido == nil {
+		return nil, domain.ErrPedidoNaoEncontrado
+	}
+	return pedido, nil
 }
 
-func (s *PedidoService) ListarPedidosPorStatus(ctx context.Context, status domain.StatusPedido) ([]*domain.Pedido, error) {
-	if !domain.IsStatusValido(status) {
-		return nil, errors.New("status inválido")
+func (s *PedidoService) ListarPedidos(ctx context.Context, filtro domain.FiltroPedidos) (_ *domain.Pagina[*domain.Pedido], err error) {
+	ctx, span := iniciarSpan(ctx, "PedidoService.ListarPedidos")
+	defer func() { finalizarSpan(span, err) }()
+
+	if err := filtro.Validar(); err != nil {
+		return nil, err
 	}
-	return s.pedidoRepository.ListarPorStatus(ctx, status)
+	return s.pedidoRepository.Listar(ctx, filtro)
 }
 
-func (s *PedidoService) ListarPedidosPorCliente(ctx context.Context, clienteID string) ([]*domain.Pedido, error) {
-	return s.pedidoRepository.ListarPorCliente(ctx, clienteID)
-}
+// AtualizarStatusPedido altera o status e devolve o pedido com a nova versão.
+// Versão zero dispensa a conferência com a versão informada pelo cliente; a
+// versão lida aqui continua protegendo contra alterações simultâneas.
+func (s *PedidoService) AtualizarStatusPedido(ctx context.Context, id string, status domain.StatusPedido, versao int64) (_ *domain.Pedido, err error) {
+	ctx, span := iniciarSpan(ctx, "PedidoService.AtualizarStatusPedido", attribute.String("pedido.id", id), attribute.String("pedido.status", string(status)))
+	defer func() { finalizarSpan(span, err) }()
 
-func (s *PedidoService) AtualizarStatusPedido(ctx context.Context, id string, status domain.StatusPedido) error {
 	if !domain.IsStatusValido(status) {
-		return errors.New("status inválido")
+		return nil, domain.NovoErroValidacao("status", "INVALIDO", "status inválido")
 	}
 
 	pedido, err := s.pedidoRepository.BuscarPorID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if pedido == nil {
-		return errors.New("pedido não encontrado")
+		return nil, domain.ErrPedidoNaoEncontrado
+	}
+	if versao != 0 && pedido.Versao != versao {
+		return nil, domain.ErrVersaoDesatualizada
 	}
 
+	anterior, desde := pedido.Status, pedido.UpdatedAt
 	pedido.AtualizarStatus(status)
-	return s.pedidoRepository.Atualizar(ctx, pedido)
+	if err := s.pedidoRepository.Atualizar(ctx, pedido); err != nil {
+		return nil, err
+	}
+
+	if anterior != status {
+		s.metricas.StatusAlterado(pedido, anterior, pedido.UpdatedAt.Sub(desde))
+	}
+	logger.DoContexto(ctx).Info("status do pedido alterado", "pedido_id", pedido.ID, "de", anterior, "para", status)
+
+	return pedido, nil
 }
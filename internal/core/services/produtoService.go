@@ -5,71 +5,310 @@ import (
 	"errors"
 	"soat-fiap/internal/core/domain"
 	"soat-fiap/internal/core/ports"
+	"soat-fiap/pkg/logger"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	LimiteBuscaPadrao = 20
+	LimiteBuscaMaximo = 50
 )
 
 type ProdutoService struct {
 	repository ports.ProdutoRepository
+	// categorias confere a categoria dos produtos cadastrados e alterados.
+	categorias    ports.CategoriaRepository
+	armazenamento ports.ArmazenamentoArquivos
+	indice        *indiceProdutos
 }
 
-func NovoProdutoService(repository ports.ProdutoRepository) *ProdutoService {
+func NovoProdutoService(repository ports.ProdutoRepository, categorias ports.CategoriaRepository, armazenamento ports.ArmazenamentoArquivos) *ProdutoService {
 	return &ProdutoService{
-		repository: repository,
+		repository:    repository,
+		categorias:    categorias,
+		armazenamento: armazenamento,
+		indice:        novoIndiceProdutos(),
 	}
 }
 
-func (s *ProdutoService) CriarProduto(ctx context.Context, nome, descricao string, preco float64, categoria domain.Categoria) (*domain.Produto, error) {
+func (s *ProdutoService) CriarProduto(ctx context.Context, nome, descricao string, preco float64, categoria domain.Categoria) (_ *domain.Produto, err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.CriarProduto")
+	defer func() { finalizarSpan(span, err) }()
+
 	id := uuid.New().String()
 
 	produto, err := domain.NovoProduto(id, nome, descricao, preco, categoria)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.validarCategoria(ctx, categoria, ""); err != nil {
+		return nil, err
+	}
 
 	err = s.repository.Criar(ctx, produto)
 	if err != nil {
 		return nil, err
 	}
 
+	s.indice.atualizar(produto)
+	logger.DoContexto(ctx).Info("produto cadastrado", "produto_id", produto.ID, "categoria", produto.Categoria)
 	return produto, nil
 }
 
-func (s *ProdutoService) BuscarProdutoPorID(ctx context.Context, id string) (*domain.Produto, error) {
-	return s.repository.BuscarPorID(ctx, id)
-}
+func (s *ProdutoService) BuscarProdutoPorID(ctx context.Context, id string) (_ *domain.Produto, err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.BuscarProdutoPorID", attribute.String("produto.id", id))
+	defer func() { finalizarSpan(span, err) }()
 
-func (s *ProdutoService) ListarProdutos(ctx context.Context) ([]*domain.Produto, error) {
-	return s.repository.Listar(ctx)
+	produto, err := s.repository.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if produto == nil {
+		return nil, domain.ErrProdutoNaoEncontrado
+	}
+
+	s.preencherURLs(produto)
+	return produto, nil
 }
 
-func (s *ProdutoService) ListarProdutosPorCategoria(ctx context.Context, categoria domain.Categoria) ([]*domain.Produto, error) {
-	if !domain.IsCategoriaValida(categoria) {
-		return nil, errors.New("categoria inválida")
+func (s *ProdutoService) ListarProdutos(ctx context.Context, filtro domain.FiltroProdutos) (_ *domain.Pagina[*domain.Produto], err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.ListarProdutos")
+	defer func() { finalizarSpan(span, err) }()
+
+	if err := filtro.Validar(); err != nil {
+		return nil, err
+	}
+	if filtro.Categoria != "" {
+		categoria, err := s.categorias.BuscarPorCodigo(ctx, filtro.Categoria)
+		if err != nil {
+			return nil, err
+		}
+		if categoria == nil {
+			return nil, domain.NovoErroValidacao("categoria", "INVALIDO", "categoria inexistente")
+		}
+	}
+
+	pagina, err := s.repository.Listar(ctx, filtro)
+	if err != nil {
+		return nil, err
 	}
-	return s.repository.ListarPorCategoria(ctx, categoria)
+	s.preencherURLs(pagina.Itens...)
+	return pagina, nil
 }
 
-func (s *ProdutoService) AtualizarProduto(ctx context.Context, produto *domain.Produto) error {
+// AtualizarProduto grava as alterações somente se produto.Versao ainda for a versão
+// atual; caso contrário devolve ErrVersaoDesatualizada. Em caso de sucesso a
+// versão é incrementada.
+func (s *ProdutoService) AtualizarProduto(ctx context.Context, produto *domain.Produto) (err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.AtualizarProduto", attribute.String("produto.id", produto.ID))
+	defer func() { finalizarSpan(span, err) }()
+
 	produtoExistente, err := s.repository.BuscarPorID(ctx, produto.ID)
 	if err != nil {
 		return err
 	}
 	if produtoExistente == nil {
-		return errors.New("produto não encontrado")
+		return domain.ErrProdutoNaoEncontrado
+	}
+	if produtoExistente.Versao != produto.Versao {
+		return domain.ErrVersaoDesatualizada
 	}
 
 	err = produto.Validar()
 	if err != nil {
 		return err
 	}
+	if err := s.validarCategoria(ctx, produto.Categoria, produtoExistente.Categoria); err != nil {
+		return err
+	}
 
 	produto.UpdatedAt = time.Now()
 
-	return s.repository.Atualizar(ctx, produto)
+	if err := s.repository.Atualizar(ctx, produto); err != nil {
+		return err
+	}
+
+	s.indice.atualizar(produto)
+	logger.DoContexto(ctx).Info("produto atualizado", "produto_id", produto.ID)
+	return nil
+}
+
+// validarCategoria exige que a categoria exista e esteja ativa. atual é a
+// categoria que o produto já tem: mantê-la é permitido mesmo que tenha sido
+// desativada, para que o produto continue editável.
+func (s *ProdutoService) validarCategoria(ctx context.Context, categoria, atual domain.Categoria) error {
+	if categoria == atual {
+		return nil
+	}
+
+	cadastrada, err := s.categorias.BuscarPorCodigo(ctx, categoria)
+	if err != nil {
+		return err
+	}
+	if cadastrada == nil {
+		return domain.NovoErroValidacao("categoria", "INVALIDO", "categoria inexistente")
+	}
+	if !cadastrada.Ativa {
+		return domain.NovoErroValidacao("categoria", "CATEGORIA_INATIVA", "a categoria está desativada e não aceita novos produtos")
+	}
+	return nil
+}
+
+// DeletarProduto remove logicamente um produto. Produtos em pedidos ainda não
+// finalizados não podem ser removidos (ErrProdutoEmPedidoAberto), para que a
+// cozinha não perca o item que está preparando.
+func (s *ProdutoService) DeletarProduto(ctx context.Context, id string) (err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.DeletarProduto", attribute.String("produto.id", id))
+	defer func() { finalizarSpan(span, err) }()
+
+	// O repositório confere os pedidos abertos na própria remoção.
+	if err := s.repository.Deletar(ctx, id); err != nil {
+		return err
+	}
+
+	s.indice.remover(id)
+	logger.DoContexto(ctx).Info("produto removido", "produto_id", id)
+	return nil
+}
+
+// RestaurarProduto desfaz a remoção lógica de um produto e o devolve à busca
+// textual. Um produto ativo resulta em ErrProdutoNaoRemovido.
+func (s *ProdutoService) RestaurarProduto(ctx context.Context, id string) (_ *domain.Produto, err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.RestaurarProduto", attribute.String("produto.id", id))
+	defer func() { finalizarSpan(span, err) }()
+
+	if err := s.repository.Restaurar(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrProdutoNaoEncontrado) {
+			if ativo, errBusca := s.repository.BuscarPorID(ctx, id); errBusca == nil && ativo != nil {
+				return nil, domain.ErrProdutoNaoRemovido
+			}
+		}
+		return nil, err
+	}
+
+	produto, err := s.repository.BuscarPorID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if produto == nil {
+		return nil, domain.ErrProdutoNaoEncontrado
+	}
+
+	s.preencherURLs(produto)
+	s.indice.atualizar(produto)
+	logger.DoContexto(ctx).Info("produto restaurado", "produto_id", id)
+	return produto, nil
+}
+
+// HistoricoPrecos devolve os preços do produto, dos agendados aos mais
+// antigos, com a situação de cada um em relação a agora.
+func (s *ProdutoService) HistoricoPrecos(ctx context.Context, produtoID string) (_ []*domain.PrecoProduto, err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.HistoricoPrecos", attribute.String("produto.id", produtoID))
+	defer func() { finalizarSpan(span, err) }()
+
+	if _, err := s.BuscarProdutoPorID(ctx, produtoID); err != nil {
+		return nil, err
+	}
+
+	precos, err := s.repository.ListarPrecos(ctx, produtoID)
+	if err != nil {
+		return nil, err
+	}
+	domain.ClassificarPrecos(precos, time.Now())
+	return precos, nil
+}
+
+// AgendarPreco registra um preço que passa a valer em vigenteDesde. Os
+// pedidos usam o novo preço a partir desse instante; o preço do produto no
+// cadastro é atualizado por AplicarPrecosVigentes.
+func (s *ProdutoService) AgendarPreco(ctx context.Context, produtoID string, preco float64, vigenteDesde time.Time) (_ *domain.PrecoProduto, err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.AgendarPreco", attribute.String("produto.id", produtoID))
+	defer func() { finalizarSpan(span, err) }()
+
+	if _, err := s.BuscarProdutoPorID(ctx, produtoID); err != nil {
+		return nil, err
+	}
+
+	agendado, err := domain.NovoPrecoAgendado(produtoID, preco, vigenteDesde, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.AgendarPreco(ctx, agendado); err != nil {
+		return nil, err
+	}
+
+	agendado.Situacao = domain.PrecoAgendado
+	logger.DoContexto(ctx).Info("preço agendado", "produto_id", produtoID, "preco", preco, "vigente_desde", vigenteDesde)
+	return agendado, nil
 }
 
-func (s *ProdutoService) DeletarProduto(ctx context.Context, id string) error {
-	return s.repository.Deletar(ctx, id)
+func (s *ProdutoService) CancelarPrecoAgendado(ctx context.Context, produtoID string, id int64) (err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.CancelarPrecoAgendado", attribute.String("produto.id", produtoID), attribute.Int64("preco.id", id))
+	defer func() { finalizarSpan(span, err) }()
+
+	if err := s.repository.CancelarPrecoAgendado(ctx, produtoID, id, time.Now()); err != nil {
+		return err
+	}
+
+	logger.DoContexto(ctx).Info("preço agendado cancelado", "produto_id", produtoID, "preco_id", id)
+	return nil
+}
+
+// AplicarPrecosVigentes leva ao cadastro os preços agendados que já entraram
+// em vigor. É chamado periodicamente; o checkout não depende dele, pois
+// resolve o preço vigente no momento do pedido.
+func (s *ProdutoService) AplicarPrecosVigentes(ctx context.Context) (err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.AplicarPrecosVigentes")
+	defer func() { finalizarSpan(span, err) }()
+
+	alterados, err := s.repository.AplicarPrecosVigentes(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if alterados > 0 {
+		s.indice.invalidar()
+		logger.DoContexto(ctx).Info("preços agendados aplicados", "produtos", alterados)
+	}
+	return nil
+}
+
+// BuscarProdutos faz uma busca textual por nome e descrição, sem diferenciar
+// acentos e tolerando erros de digitação, ordenada por relevância.
+func (s *ProdutoService) BuscarProdutos(ctx context.Context, consulta string, limite int) (_ []*domain.Produto, err error) {
+	ctx, span := iniciarSpan(ctx, "ProdutoService.BuscarProdutos", attribute.Int("busca.limite", limite))
+	defer func() { finalizarSpan(span, err) }()
+
+	var erros domain.ErrosValidacao
+	if len(normalizarTermos(consulta)) == 0 {
+		erros.Adicionar("q", "OBRIGATORIO", "informe um termo de busca")
+	}
+	if limite == 0 {
+		limite = LimiteBuscaPadrao
+	}
+	if limite < 0 || limite > LimiteBuscaMaximo {
+		erros.Adicionar("limit", "FORA_DO_INTERVALO", "limit deve estar entre 1 e "+strconv.Itoa(LimiteBuscaMaximo))
+	}
+	if err := erros.Erro(); err != nil {
+		return nil, err
+	}
+
+	if err := s.indice.garantir(ctx, s.repository.Listar); err != nil {
+		return nil, err
+	}
+
+	// Os produtos do índice são compartilhados entre as buscas; cada
+	// resultado é copiado antes de receber as URLs da imagem.
+	encontrados := s.indice.buscar(consulta, limite)
+	produtos := make([]*domain.Produto, len(encontrados))
+	for i, encontrado := range encontrados {
+		copia := *encontrado
+		produtos[i] = &copia
+	}
+	s.preencherURLs(produtos...)
+	logger.DoContexto(ctx).Debug("busca de produtos", "consulta", consulta, "resultados", len(produtos))
+	return produtos, nil
 }
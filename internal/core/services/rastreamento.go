@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"soat-fiap/internal/core/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("soat-fiap/internal/core/services")
+
+// iniciarSpan abre o span de um método de serviço como filho do span do
+// contexto (o da requisição HTTP); as consultas feitas pelos repositórios
+// com o contexto devolvido ficam penduradas nele.
+func iniciarSpan(ctx context.Context, nome string, atributos ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, nome, trace.WithAttributes(atributos...))
+}
+
+// finalizarSpan encerra o span. Erros de domínio esperados (validação, não
+// encontrado, conflito) só viram o atributo erro.codigo; os demais marcam o
+// span como falho.
+func finalizarSpan(span trace.Span, err error) {
+	var erroDominio *domain.Erro
+	switch {
+	case err == nil:
+	case errors.As(err, &erroDominio) && !errors.Is(err, domain.ErrIndisponivel):
+		span.SetAttributes(attribute.String("erro.codigo", erroDominio.Codigo))
+	default:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -8,27 +8,48 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func ConfigurarRotas(r *mux.Router, clienteHandler *handlers.ClienteHandler, produtoHandler *handlers.ProdutoHandler, pedidoHandler *handlers.PedidoHandler, healthHandler *handlers.HealthHandler) {
+func ConfigurarRotas(r *mux.Router, clienteHandler *handlers.ClienteHandler, produtoHandler *handlers.ProdutoHandler, categoriaHandler *handlers.CategoriaHandler, pedidoHandler *handlers.PedidoHandler, healthHandler *handlers.HealthHandler) {
 	api := r.PathPrefix("/api/v1").Subrouter()
 
 	api.HandleFunc("/health", healthHandler.HealthCheck).Methods(http.MethodGet)
+	api.HandleFunc("/health/live", healthHandler.Liveness).Methods(http.MethodGet)
+	api.HandleFunc("/health/ready", healthHandler.Readiness).Methods(http.MethodGet)
 
 	api.HandleFunc("/clientes", clienteHandler.CriarCliente).Methods(http.MethodPost)
 	api.HandleFunc("/clientes", clienteHandler.ListarClientes).Methods(http.MethodGet)
 	api.HandleFunc("/clientes/cpf/{cpf}", clienteHandler.BuscarClientePorCPF).Methods(http.MethodGet)
 	api.HandleFunc("/clientes/{id}", clienteHandler.BuscarClientePorID).Methods(http.MethodGet)
 	api.HandleFunc("/clientes/{id}", clienteHandler.AtualizarCliente).Methods(http.MethodPut)
+	api.HandleFunc("/clientes/{id}", clienteHandler.AtualizarClienteParcial).Methods(http.MethodPatch)
 	api.HandleFunc("/clientes/{id}", clienteHandler.DeletarCliente).Methods(http.MethodDelete)
+	api.HandleFunc("/clientes/{id}/restaurar", clienteHandler.RestaurarCliente).Methods(http.MethodPost)
 
 	api.HandleFunc("/produtos", produtoHandler.CriarProduto).Methods(http.MethodPost)
 	api.HandleFunc("/produtos", produtoHandler.ListarProdutos).Methods(http.MethodGet)
+	api.HandleFunc("/produtos/busca", produtoHandler.BuscarProdutos).Methods(http.MethodGet)
 	api.HandleFunc("/produtos/{id}", produtoHandler.BuscarProdutoPorID).Methods(http.MethodGet)
 	api.HandleFunc("/produtos/{id}", produtoHandler.AtualizarProduto).Methods(http.MethodPut)
+	api.HandleFunc("/produtos/{id}", produtoHandler.AtualizarProdutoParcial).Methods(http.MethodPatch)
 	api.HandleFunc("/produtos/{id}", produtoHandler.DeletarProduto).Methods(http.MethodDelete)
+	api.HandleFunc("/produtos/{id}/restaurar", produtoHandler.RestaurarProduto).Methods(http.MethodPost)
+	api.HandleFunc("/produtos/{id}/precos", produtoHandler.HistoricoPrecos).Methods(http.MethodGet)
+	api.HandleFunc("/produtos/{id}/precos", produtoHandler.AgendarPreco).Methods(http.MethodPost)
+	api.HandleFunc("/produtos/{id}/precos/{precoId}", produtoHandler.CancelarPrecoAgendado).Methods(http.MethodDelete)
+	api.HandleFunc("/produtos/{id}/imagem", produtoHandler.EnviarImagem).Methods(http.MethodPost)
+
+	api.HandleFunc("/categorias", categoriaHandler.CriarCategoria).Methods(http.MethodPost)
+	api.HandleFunc("/categorias", categoriaHandler.ListarCategorias).Methods(http.MethodGet)
+	api.HandleFunc("/categorias/{codigo}", categoriaHandler.BuscarCategoria).Methods(http.MethodGet)
+	api.HandleFunc("/categorias/{codigo}", categoriaHandler.AtualizarCategoria).Methods(http.MethodPut)
+	api.HandleFunc("/categorias/{codigo}", categoriaHandler.AtualizarCategoriaParcial).Methods(http.MethodPatch)
+	api.HandleFunc("/categorias/{codigo}", categoriaHandler.DeletarCategoria).Methods(http.MethodDelete)
 
 	api.HandleFunc("/checkout", pedidoHandler.FakeCheckout).Methods(http.MethodPost)
 	api.HandleFunc("/pedidos", pedidoHandler.FakeCheckout).Methods(http.MethodPost)
 	api.HandleFunc("/pedidos", pedidoHandler.ListarPedidos).Methods(http.MethodGet)
 	api.HandleFunc("/pedidos/{id}", pedidoHandler.BuscarPedidoPorID).Methods(http.MethodGet)
 	api.HandleFunc("/pedidos/{id}/status", pedidoHandler.AtualizarStatusPedido).Methods(http.MethodPatch)
+
+	api.HandleFunc("/admin/clientes/removidos", clienteHandler.ListarClientesRemovidos).Methods(http.MethodGet)
+	api.HandleFunc("/admin/produtos/removidos", produtoHandler.ListarProdutosRemovidos).Methods(http.MethodGet)
 }
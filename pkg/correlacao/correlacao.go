@@ -0,0 +1,47 @@
+// Package correlacao carrega o ID da requisição (X-Request-ID) pelo
+// context.Context, para que logs, respostas de erro e chamadas a outros
+// serviços possam ser ligados ao relato de quem fez a requisição.
+package correlacao
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Cabecalho é o cabeçalho HTTP que transporta o ID, na requisição e na
+// resposta.
+const Cabecalho = "X-Request-ID"
+
+const tamanhoMaximo = 128
+
+type chaveContexto struct{}
+
+// NoContexto devolve um contexto que carrega o ID.
+func NoContexto(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, chaveContexto{}, id)
+}
+
+// DoContexto devolve o ID guardado no contexto ou "" se não houver.
+func DoContexto(ctx context.Context) string {
+	id, _ := ctx.Value(chaveContexto{}).(string)
+	return id
+}
+
+// Normalizar devolve o ID recebido se ele for aceitável ou um novo UUID. IDs
+// longos ou com caracteres fora de [A-Za-z0-9._:-] são descartados, para não
+// permitir injeção de conteúdo nos logs e cabeçalhos.
+func Normalizar(id string) string {
+	if id == "" || len(id) > tamanhoMaximo {
+		return uuid.NewString()
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return uuid.NewString()
+		}
+	}
+	return id
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/XSAM/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Drivers suportados. Os nomes também identificam o diretório de migrações
+// de cada banco.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Modos de TLS aceitos em Conexao.TLS, traduzidos para o parâmetro de cada
+// driver. TLSObrigatorio cifra sem validar o certificado; TLSVerificado
+// também valida a cadeia e o nome do host.
+const (
+	TLSDesativado  = "disable"
+	TLSPreferido   = "prefer"
+	TLSObrigatorio = "require"
+	TLSVerificado  = "verify"
+)
+
+const (
+	esperaInicialReconexao = 500 * time.Millisecond
+	esperaMaximaReconexao  = 10 * time.Second
+)
+
+// Conexao reúne os parâmetros de conexão de todos os drivers. Caminho só é
+// usado pelo SQLite; os demais campos, pelos bancos com servidor.
+type Conexao struct {
+	Driver  string
+	Host    string
+	Porta   string
+	Usuario string
+	Senha   string
+	Banco   string
+	Caminho string
+
+	TLS          string
+	TempoConexao time.Duration
+	// TempoLeitura e TempoEscrita limitam cada operação de E/S no MySQL.
+	TempoLeitura time.Duration
+	TempoEscrita time.Duration
+
+	Pool Pool
+
+	// PrazoInicializacao é quanto Conectar insiste enquanto o banco não
+	// responde, com espera exponencial entre as tentativas. Zero faz uma
+	// única tentativa.
+	PrazoInicializacao time.Duration
+}
+
+// Pool configura o pool de conexões do database/sql. Valores zero mantêm o
+// padrão do pacote (sem limite).
+type Pool struct {
+	MaxAbertas       int
+	MaxOciosas       int
+	VidaMaxima       time.Duration
+	OciosidadeMaxima time.Duration
+}
+
+var drivers = map[string]struct {
+	nome    string
+	dsn     func(Conexao) string
+	sistema attribute.KeyValue
+}{
+	DriverMySQL:    {nome: "mysql", dsn: dsnMySQL, sistema: semconv.DBSystemMySQL},
+	DriverPostgres: {nome: "pgx", dsn: dsnPostgres, sistema: semconv.DBSystemPostgreSQL},
+	DriverSQLite:   {nome: "sqlite3", dsn: dsnSQLite, sistema: semconv.DBSystemSqlite},
+}
+
+// Conectar abre o pool do driver informado e aguarda o banco responder. O
+// esquema não é criado aqui: use o subcomando "migrate" (ver Migrador).
+func Conectar(c Conexao) (*sql.DB, error) {
+	driver, ok := drivers[c.Driver]
+	if !ok {
+		return nil, fmt.Errorf("driver de banco não suportado: %s", c.Driver)
+	}
+	if c.TLS == "" {
+		c.TLS = TLSDesativado
+	}
+	if _, ok := modosTLSMySQL[c.TLS]; !ok {
+		return nil, fmt.Errorf("modo de TLS inválido: %s", c.TLS)
+	}
+
+	// O driver é envolvido pelo otelsql: cada consulta feita com o contexto
+	// de uma requisição vira um span filho dela. Consultas sem trace no
+	// contexto (ex.: o Ping da inicialização) não geram spans.
+	db, err := otelsql.Open(driver.nome, driver.dsn(c),
+		otelsql.WithAttributes(driver.sistema),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter:           temSpanNoContexto,
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(c.Pool.MaxAbertas)
+	db.SetMaxIdleConns(c.Pool.MaxOciosas)
+	db.SetConnMaxLifetime(c.Pool.VidaMaxima)
+	db.SetConnMaxIdleTime(c.Pool.OciosidadeMaxima)
+	if c.Driver == DriverSQLite {
+		// O SQLite aceita um único escritor por vez; uma conexão só evita
+		// disputas pelo bloqueio do arquivo dentro do próprio processo.
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := aguardar(db, c.PrazoInicializacao); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// aguardar tenta o Ping até o banco responder ou o prazo acabar, dobrando a
+// espera entre as tentativas. Evita que a API morra (e o orquestrador entre
+// em loop de reinício) quando sobe antes do banco.
+func aguardar(db *sql.DB, prazo time.Duration) error {
+	if prazo <= 0 {
+		return db.Ping()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), prazo)
+	defer cancel()
+
+	espera := esperaInicialReconexao
+	for tentativa := 1; ; tentativa++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		limite, _ := ctx.Deadline()
+		if time.Until(limite) < espera {
+			return fmt.Errorf("banco indisponível após %d tentativas: %w", tentativa, err)
+		}
+
+		slog.Warn("banco indisponível", "tentativa", tentativa, "erro", err, "nova_tentativa_em", espera.String())
+		time.Sleep(espera)
+		espera = min(espera*2, esperaMaximaReconexao)
+	}
+}
+
+func temSpanNoContexto(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
@@ -0,0 +1,420 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"soat-fiap/pkg/logger"
+)
+
+//go:embed migrations
+var arquivosMigracoes embed.FS
+
+// tempoEsperaBloqueio é quanto uma réplica espera enquanto outra aplica migrações.
+const tempoEsperaBloqueio = 60 * time.Second
+
+// Migracao é um par de scripts numerados em migrations/<driver>, no formato
+// 0001_descricao.up.sql e 0001_descricao.down.sql.
+type Migracao struct {
+	Versao int
+	Nome   string
+	up     string
+	down   string
+}
+
+// EstadoMigracao indica se uma migração conhecida já foi aplicada ao banco.
+type EstadoMigracao struct {
+	Versao     int
+	Nome       string
+	Aplicada   bool
+	AplicadaEm time.Time
+}
+
+// dialetoMigracao reúne o SQL que muda entre os bancos suportados.
+type dialetoMigracao struct {
+	criarTabela string
+	// existeTabela conta as tabelas schema_migrations visíveis, para que a
+	// leitura do estado não precise criá-la.
+	existeTabela string
+	inserir      string
+	remover      string
+	bloquear     func(ctx context.Context, conn *sql.Conn) error
+	// desbloquear recebe o erro das migrações, para que o SQLite desfaça a
+	// transação em vez de confirmar um script aplicado pela metade.
+	desbloquear func(ctx context.Context, conn *sql.Conn, falha error) error
+}
+
+var dialetosMigracao = map[string]dialetoMigracao{
+	DriverMySQL: {
+		criarTabela: `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			nome VARCHAR(255) NOT NULL,
+			aplicada_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		existeTabela: "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'schema_migrations'",
+		inserir:      "INSERT INTO schema_migrations (version, nome) VALUES (?, ?)",
+		remover:      "DELETE FROM schema_migrations WHERE version = ?",
+		bloquear: func(ctx context.Context, conn *sql.Conn) error {
+			var obtido sql.NullInt64
+			err := conn.QueryRowContext(ctx, "SELECT GET_LOCK('schema_migrations', ?)", int(tempoEsperaBloqueio.Seconds())).Scan(&obtido)
+			if err != nil {
+				return err
+			}
+			if obtido.Int64 != 1 {
+				return errors.New("tempo esgotado aguardando outra instância terminar as migrações")
+			}
+			return nil
+		},
+		desbloquear: func(ctx context.Context, conn *sql.Conn, _ error) error {
+			_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK('schema_migrations')")
+			return err
+		},
+	},
+	DriverPostgres: {
+		criarTabela: `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			nome VARCHAR(255) NOT NULL,
+			aplicada_em TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`,
+		existeTabela: "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'schema_migrations'",
+		inserir:      "INSERT INTO schema_migrations (version, nome) VALUES ($1, $2)",
+		remover:      "DELETE FROM schema_migrations WHERE version = $1",
+		// pg_advisory_lock espera indefinidamente; o prazo vem do contexto.
+		bloquear: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext('schema_migrations'))")
+			return err
+		},
+		desbloquear: func(ctx context.Context, conn *sql.Conn, _ error) error {
+			_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext('schema_migrations'))")
+			return err
+		},
+	},
+	// O SQLite não tem bloqueios nomeados; uma transação IMMEDIATE reserva a
+	// escrita no arquivo até o fim das migrações, e o DDL participa dela.
+	DriverSQLite: {
+		criarTabela: `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			nome TEXT NOT NULL,
+			aplicada_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		existeTabela: "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'",
+		inserir:      "INSERT INTO schema_migrations (version, nome) VALUES (?, ?)",
+		remover:      "DELETE FROM schema_migrations WHERE version = ?",
+		bloquear: func(ctx context.Context, conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE")
+			return err
+		},
+		desbloquear: func(ctx context.Context, conn *sql.Conn, falha error) error {
+			comando := "COMMIT"
+			if falha != nil {
+				comando = "ROLLBACK"
+			}
+			_, err := conn.ExecContext(ctx, comando)
+			return err
+		},
+	},
+}
+
+// Migrador aplica e reverte as migrações embutidas no binário. As operações
+// que alteram o esquema seguram um bloqueio no banco para que duas réplicas
+// não migrem ao mesmo tempo.
+type Migrador struct {
+	db        *sql.DB
+	dialeto   dialetoMigracao
+	migracoes []Migracao
+}
+
+func NovoMigrador(db *sql.DB, driver string) (*Migrador, error) {
+	dialeto, ok := dialetosMigracao[driver]
+	if !ok {
+		return nil, fmt.Errorf("migrações não suportadas para o driver %q", driver)
+	}
+
+	migracoes, err := carregarMigracoes(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrador{
+		db:        db,
+		dialeto:   dialeto,
+		migracoes: migracoes,
+	}, nil
+}
+
+func carregarMigracoes(driver string) ([]Migracao, error) {
+	dir := path.Join("migrations", driver)
+	arquivos, err := fs.ReadDir(arquivosMigracoes, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	porVersao := make(map[int]*Migracao)
+	for _, arquivo := range arquivos {
+		nome := arquivo.Name()
+		base, direcao, ok := strings.Cut(strings.TrimSuffix(nome, ".sql"), ".")
+		if !ok || (direcao != "up" && direcao != "down") {
+			return nil, fmt.Errorf("nome de migração inválido: %s", nome)
+		}
+		numero, descricao, _ := strings.Cut(base, "_")
+		versao, err := strconv.Atoi(numero)
+		if err != nil {
+			return nil, fmt.Errorf("nome de migração inválido: %s", nome)
+		}
+
+		conteudo, err := fs.ReadFile(arquivosMigracoes, path.Join(dir, nome))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := porVersao[versao]
+		if !ok {
+			m = &Migracao{Versao: versao, Nome: descricao}
+			porVersao[versao] = m
+		}
+		if direcao == "up" {
+			m.up = string(conteudo)
+		} else {
+			m.down = string(conteudo)
+		}
+	}
+
+	migracoes := make([]Migracao, 0, len(porVersao))
+	for _, m := range porVersao {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migração %04d_%s sem script up ou down", m.Versao, m.Nome)
+		}
+		migracoes = append(migracoes, *m)
+	}
+	sort.Slice(migracoes, func(i, j int) bool { return migracoes[i].Versao < migracoes[j].Versao })
+
+	return migracoes, nil
+}
+
+// UltimaVersao é a versão da migração mais recente embutida no binário.
+func (m *Migrador) UltimaVersao() int {
+	if len(m.migracoes) == 0 {
+		return 0
+	}
+	return m.migracoes[len(m.migracoes)-1].Versao
+}
+
+// Subir aplica todas as migrações pendentes.
+func (m *Migrador) Subir(ctx context.Context) error {
+	return m.Para(ctx, m.UltimaVersao())
+}
+
+// Descer reverte as últimas migrações aplicadas.
+func (m *Migrador) Descer(ctx context.Context, passos int) error {
+	return m.executar(ctx, func(conn *sql.Conn) error {
+		aplicadas, err := m.aplicadas(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migracoes) - 1; i >= 0 && passos > 0; i-- {
+			if _, ok := aplicadas[m.migracoes[i].Versao]; !ok {
+				continue
+			}
+			if err := m.reverter(ctx, conn, m.migracoes[i]); err != nil {
+				return err
+			}
+			passos--
+		}
+		return nil
+	})
+}
+
+// Para leva o banco até a versão informada, aplicando ou revertendo o que for
+// necessário. Versão 0 reverte todas as migrações.
+func (m *Migrador) Para(ctx context.Context, versao int) error {
+	if versao != 0 && !m.conhece(versao) {
+		return fmt.Errorf("versão de migração desconhecida: %d", versao)
+	}
+
+	return m.executar(ctx, func(conn *sql.Conn) error {
+		aplicadas, err := m.aplicadas(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migracoes) - 1; i >= 0; i-- {
+			migracao := m.migracoes[i]
+			if _, ok := aplicadas[migracao.Versao]; ok && migracao.Versao > versao {
+				if err := m.reverter(ctx, conn, migracao); err != nil {
+					return err
+				}
+			}
+		}
+		for _, migracao := range m.migracoes {
+			if _, ok := aplicadas[migracao.Versao]; !ok && migracao.Versao <= versao {
+				if err := m.aplicar(ctx, conn, migracao); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status lista as migrações conhecidas e se cada uma já foi aplicada. Só lê
+// o banco: não aguarda o bloqueio, então pode refletir uma migração ainda em
+// andamento, e sem a tabela schema_migrations considera todas pendentes. Por
+// isso pode ser usado pela prontidão com um usuário sem permissão de DDL.
+func (m *Migrador) Status(ctx context.Context) ([]EstadoMigracao, error) {
+	var existe int
+	if err := m.db.QueryRowContext(ctx, m.dialeto.existeTabela).Scan(&existe); err != nil {
+		return nil, err
+	}
+
+	aplicadas := map[int]time.Time{}
+	if existe > 0 {
+		var err error
+		if aplicadas, err = m.aplicadas(ctx, m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	estados := make([]EstadoMigracao, 0, len(m.migracoes))
+	for _, migracao := range m.migracoes {
+		aplicadaEm, ok := aplicadas[migracao.Versao]
+		estados = append(estados, EstadoMigracao{
+			Versao:     migracao.Versao,
+			Nome:       migracao.Nome,
+			Aplicada:   ok,
+			AplicadaEm: aplicadaEm,
+		})
+	}
+	return estados, nil
+}
+
+// Pendentes retorna quantas migrações ainda não foram aplicadas.
+func (m *Migrador) Pendentes(ctx context.Context) (int, error) {
+	estados, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	pendentes := 0
+	for _, estado := range estados {
+		if !estado.Aplicada {
+			pendentes++
+		}
+	}
+	return pendentes, nil
+}
+
+func (m *Migrador) conhece(versao int) bool {
+	for _, migracao := range m.migracoes {
+		if migracao.Versao == versao {
+			return true
+		}
+	}
+	return false
+}
+
+// executar roda fn em uma conexão dedicada, já que os bloqueios do banco
+// pertencem à sessão, segurando o bloqueio de migração e garantindo que a
+// tabela de controle exista.
+func (m *Migrador) executar(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctxBloqueio, cancel := context.WithTimeout(ctx, tempoEsperaBloqueio+5*time.Second)
+	defer cancel()
+	if err := m.dialeto.bloquear(ctxBloqueio, conn); err != nil {
+		return fmt.Errorf("erro ao obter bloqueio de migração: %w", err)
+	}
+	defer func() {
+		if errLiberar := m.dialeto.desbloquear(context.Background(), conn, err); errLiberar != nil {
+			logger.DoContexto(ctx).Error("erro ao liberar bloqueio de migração", "erro", errLiberar)
+			if err == nil {
+				err = errLiberar
+			}
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, m.dialeto.criarTabela); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// consultor é atendido por *sql.DB e *sql.Conn.
+type consultor interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func (m *Migrador) aplicadas(ctx context.Context, db consultor) (map[int]time.Time, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version, aplicada_em FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aplicadas := make(map[int]time.Time)
+	for rows.Next() {
+		var versao int
+		var aplicadaEm time.Time
+		if err := rows.Scan(&versao, &aplicadaEm); err != nil {
+			return nil, err
+		}
+		aplicadas[versao] = aplicadaEm
+	}
+	return aplicadas, rows.Err()
+}
+
+func (m *Migrador) aplicar(ctx context.Context, conn *sql.Conn, migracao Migracao) error {
+	logger.DoContexto(ctx).Info("aplicando migração", "versao", migracao.Versao, "nome", migracao.Nome)
+	if err := executarScript(ctx, conn, migracao.up); err != nil {
+		return fmt.Errorf("migração %04d_%s: %w", migracao.Versao, migracao.Nome, err)
+	}
+	_, err := conn.ExecContext(ctx, m.dialeto.inserir, migracao.Versao, migracao.Nome)
+	return err
+}
+
+func (m *Migrador) reverter(ctx context.Context, conn *sql.Conn, migracao Migracao) error {
+	logger.DoContexto(ctx).Info("revertendo migração", "versao", migracao.Versao, "nome", migracao.Nome)
+	if err := executarScript(ctx, conn, migracao.down); err != nil {
+		return fmt.Errorf("reversão %04d_%s: %w", migracao.Versao, migracao.Nome, err)
+	}
+	_, err := conn.ExecContext(ctx, m.dialeto.remover, migracao.Versao)
+	return err
+}
+
+// executarScript roda cada comando do script separadamente, já que o driver
+// não aceita vários comandos em uma única chamada. Os comandos são separados
+// por ";" no fim da linha.
+func executarScript(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, comando := range strings.Split(script, ";\n") {
+		comando = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(comando), ";"))
+		if comando == "" || apenasComentarios(comando) {
+			continue
+		}
+		if _, err := conn.ExecContext(ctx, comando); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func apenasComentarios(comando string) bool {
+	for _, linha := range strings.Split(comando, "\n") {
+		linha = strings.TrimSpace(linha)
+		if linha != "" && !strings.HasPrefix(linha, "--") {
+			return false
+		}
+	}
+	return true
+}
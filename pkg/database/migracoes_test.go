@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func abrirSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Conectar(Conexao{Driver: DriverSQLite, Caminho: filepath.Join(t.TempDir(), "teste.db")})
+	if err != nil {
+		t.Fatalf("Conectar: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func existeTabela(t *testing.T, db *sql.DB, tabela string) bool {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", tabela).Scan(&n); err != nil {
+		t.Fatalf("consultar sqlite_master: %v", err)
+	}
+	return n > 0
+}
+
+// Um script que falha no meio não pode deixar o que já executou aplicado: no
+// SQLite as migrações rodam na transação que serve de bloqueio.
+func TestSubirDesfazMigracaoComFalhaNoSQLite(t *testing.T) {
+	db := abrirSQLite(t)
+	migrador := &Migrador{
+		db:      db,
+		dialeto: dialetosMigracao[DriverSQLite],
+		migracoes: []Migracao{{
+			Versao: 1,
+			Nome:   "quebrada",
+			up:     "CREATE TABLE parcial (id INTEGER PRIMARY KEY);\nINSERT INTO inexistente VALUES (1);\n",
+			down:   "DROP TABLE parcial;\n",
+		}},
+	}
+
+	if err := migrador.Subir(context.Background()); err == nil {
+		t.Fatal("Subir deveria falhar")
+	}
+
+	if existeTabela(t, db, "parcial") {
+		t.Error("a tabela criada antes da falha não foi desfeita")
+	}
+	if existeTabela(t, db, "schema_migrations") {
+		t.Error("schema_migrations não deveria existir depois do rollback")
+	}
+}
+
+// A prontidão consulta Pendentes a cada poucos segundos; a leitura não pode
+// criar a tabela de controle nem depender de permissão de DDL.
+func TestStatusSoLeOBanco(t *testing.T) {
+	db := abrirSQLite(t)
+	migrador, err := NovoMigrador(db, DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pendentes, err := migrador.Pendentes(context.Background())
+	if err != nil {
+		t.Fatalf("Pendentes em banco vazio: %v", err)
+	}
+	if pendentes != len(migrador.migracoes) {
+		t.Errorf("esperadas %d migrações pendentes, obtidas %d", len(migrador.migracoes), pendentes)
+	}
+	if existeTabela(t, db, "schema_migrations") {
+		t.Error("Pendentes não deve criar schema_migrations")
+	}
+
+	if err := migrador.Subir(context.Background()); err != nil {
+		t.Fatalf("Subir: %v", err)
+	}
+	if pendentes, err := migrador.Pendentes(context.Background()); err != nil || pendentes != 0 {
+		t.Errorf("depois de Subir: esperadas 0 pendentes, obtidas %d, %v", pendentes, err)
+	}
+}
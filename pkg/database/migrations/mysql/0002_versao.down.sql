@@ -0,0 +1,3 @@
+ALTER TABLE pedidos DROP COLUMN versao;
+ALTER TABLE produtos DROP COLUMN versao;
+ALTER TABLE clientes DROP COLUMN versao;
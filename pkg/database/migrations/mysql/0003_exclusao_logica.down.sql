@@ -0,0 +1,3 @@
+DROP INDEX idx_pedido_itens_produto_id ON pedido_itens;
+ALTER TABLE produtos DROP COLUMN deleted_at;
+ALTER TABLE clientes DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE produtos DROP FOREIGN KEY fk_produtos_categoria;
+DROP TABLE IF EXISTS categoria_traducoes;
+DROP TABLE IF EXISTS categorias;
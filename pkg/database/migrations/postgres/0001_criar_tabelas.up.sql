@@ -0,0 +1,49 @@
+-- Estrutura inicial. Datas usam TIMESTAMPTZ e valores NUMERIC, sem a
+-- conversão para texto feita nos demais bancos.
+CREATE TABLE IF NOT EXISTS clientes (
+	id VARCHAR(36) PRIMARY KEY,
+	nome VARCHAR(100) NOT NULL,
+	cpf VARCHAR(11) NOT NULL UNIQUE,
+	email VARCHAR(100) NOT NULL,
+	telefone VARCHAR(20) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS produtos (
+	id VARCHAR(36) PRIMARY KEY,
+	nome VARCHAR(100) NOT NULL,
+	descricao TEXT NOT NULL,
+	preco NUMERIC(10,2) NOT NULL,
+	categoria VARCHAR(20) NOT NULL,
+	disponivel BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_produtos_categoria ON produtos (categoria);
+
+CREATE TABLE IF NOT EXISTS pedidos (
+	id VARCHAR(36) PRIMARY KEY,
+	cliente_id VARCHAR(36) NULL,
+	valor_total NUMERIC(10,2) NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_status ON pedidos (status);
+CREATE INDEX IF NOT EXISTS idx_cliente_id ON pedidos (cliente_id);
+CREATE INDEX IF NOT EXISTS idx_created_at ON pedidos (created_at);
+
+CREATE TABLE IF NOT EXISTS pedido_itens (
+	id SERIAL PRIMARY KEY,
+	pedido_id VARCHAR(36) NOT NULL REFERENCES pedidos (id) ON DELETE CASCADE,
+	produto_id VARCHAR(36) NOT NULL,
+	nome VARCHAR(100) NOT NULL,
+	preco NUMERIC(10,2) NOT NULL,
+	quantidade INTEGER NOT NULL,
+	observacao TEXT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pedido_id ON pedido_itens (pedido_id);
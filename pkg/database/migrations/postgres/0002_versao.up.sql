@@ -0,0 +1,6 @@
+-- Versão de cada registro para o controle de concorrência otimista: toda
+-- atualização incrementa a coluna e só é aplicada se a versão lida ainda for
+-- a atual. Registros existentes começam na versão 1.
+ALTER TABLE clientes ADD COLUMN versao BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE produtos ADD COLUMN versao BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE pedidos ADD COLUMN versao BIGINT NOT NULL DEFAULT 1;
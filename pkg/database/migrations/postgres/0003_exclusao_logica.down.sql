@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pedido_itens_produto_id;
+ALTER TABLE produtos DROP COLUMN deleted_at;
+ALTER TABLE clientes DROP COLUMN deleted_at;
@@ -0,0 +1 @@
+ALTER TABLE produtos DROP COLUMN imagem;
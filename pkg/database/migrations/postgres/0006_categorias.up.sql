@@ -0,0 +1,41 @@
+-- Categorias gerenciadas pela API. O código continua sendo o valor gravado em
+-- produtos.categoria, agora com chave estrangeira. As quatro categorias que
+-- eram fixas entram com nome, ordem e tradução para o inglês; outros códigos
+-- já usados por produtos entram ativos, com o próprio código como nome.
+CREATE TABLE IF NOT EXISTS categorias (
+	codigo VARCHAR(20) PRIMARY KEY,
+	nome VARCHAR(100) NOT NULL,
+	icone VARCHAR(255) NOT NULL DEFAULT '',
+	ordem INTEGER NOT NULL DEFAULT 0,
+	ativa BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL,
+	versao BIGINT NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS idx_categorias_ordem ON categorias (ordem, codigo);
+
+CREATE TABLE IF NOT EXISTS categoria_traducoes (
+	categoria_codigo VARCHAR(20) NOT NULL REFERENCES categorias (codigo) ON DELETE CASCADE,
+	idioma VARCHAR(35) NOT NULL,
+	nome VARCHAR(100) NOT NULL,
+	PRIMARY KEY (categoria_codigo, idioma)
+);
+
+INSERT INTO categorias (codigo, nome, ordem, created_at, updated_at) VALUES
+	('LANCHE', 'Lanches', 1, NOW(), NOW()),
+	('ACOMPANHAMENTO', 'Acompanhamentos', 2, NOW(), NOW()),
+	('BEBIDA', 'Bebidas', 3, NOW(), NOW()),
+	('SOBREMESA', 'Sobremesas', 4, NOW(), NOW());
+
+INSERT INTO categoria_traducoes (categoria_codigo, idioma, nome) VALUES
+	('LANCHE', 'en', 'Burgers'),
+	('ACOMPANHAMENTO', 'en', 'Sides'),
+	('BEBIDA', 'en', 'Drinks'),
+	('SOBREMESA', 'en', 'Desserts');
+
+INSERT INTO categorias (codigo, nome, ordem, created_at, updated_at)
+SELECT DISTINCT categoria, categoria, 100, NOW(), NOW()
+FROM produtos
+WHERE categoria NOT IN ('LANCHE', 'ACOMPANHAMENTO', 'BEBIDA', 'SOBREMESA');
+
+ALTER TABLE produtos ADD CONSTRAINT fk_produtos_categoria FOREIGN KEY (categoria) REFERENCES categorias (codigo);
@@ -0,0 +1,50 @@
+-- Estrutura inicial. As datas são gravadas como texto em UTC com largura fixa
+-- (ver o adaptador sqlite), para que a ordenação textual coincida com a
+-- cronológica. Nomes usam NOCASE para ordenar sem diferenciar maiúsculas.
+CREATE TABLE IF NOT EXISTS clientes (
+	id TEXT PRIMARY KEY,
+	nome TEXT NOT NULL COLLATE NOCASE,
+	cpf TEXT NOT NULL UNIQUE,
+	email TEXT NOT NULL,
+	telefone TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS produtos (
+	id TEXT PRIMARY KEY,
+	nome TEXT NOT NULL COLLATE NOCASE,
+	descricao TEXT NOT NULL,
+	preco REAL NOT NULL,
+	categoria TEXT NOT NULL,
+	disponivel INTEGER NOT NULL DEFAULT 1,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_produtos_categoria ON produtos (categoria);
+
+CREATE TABLE IF NOT EXISTS pedidos (
+	id TEXT PRIMARY KEY,
+	cliente_id TEXT NULL,
+	valor_total REAL NOT NULL,
+	status TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_status ON pedidos (status);
+CREATE INDEX IF NOT EXISTS idx_cliente_id ON pedidos (cliente_id);
+CREATE INDEX IF NOT EXISTS idx_created_at ON pedidos (created_at);
+
+CREATE TABLE IF NOT EXISTS pedido_itens (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pedido_id TEXT NOT NULL REFERENCES pedidos (id) ON DELETE CASCADE,
+	produto_id TEXT NOT NULL,
+	nome TEXT NOT NULL,
+	preco REAL NOT NULL,
+	quantidade INTEGER NOT NULL,
+	observacao TEXT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pedido_id ON pedido_itens (pedido_id);
@@ -0,0 +1,7 @@
+-- Versão de cada registro para o controle de concorrência otimista: toda
+-- atualização incrementa a coluna e só é aplicada se a versão lida ainda for
+-- a atual. Registros existentes começam na versão 1. DROP COLUMN na reversão
+-- exige SQLite 3.35 ou superior (incluído no driver).
+ALTER TABLE clientes ADD COLUMN versao INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE produtos ADD COLUMN versao INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE pedidos ADD COLUMN versao INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,7 @@
+-- Remoção lógica de clientes e produtos: os registros removidos ficam com
+-- deleted_at preenchido e continuam disponíveis para os pedidos antigos. O
+-- índice em pedido_itens atende a verificação de pedidos em aberto antes de
+-- remover um produto.
+ALTER TABLE clientes ADD COLUMN deleted_at TEXT;
+ALTER TABLE produtos ADD COLUMN deleted_at TEXT;
+CREATE INDEX IF NOT EXISTS idx_pedido_itens_produto_id ON pedido_itens (produto_id);
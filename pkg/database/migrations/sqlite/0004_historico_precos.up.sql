@@ -0,0 +1,14 @@
+-- Histórico de preços: cada linha é um preço e o instante a partir do qual
+-- vale, inclusive os agendados para o futuro. O preço vigente é o de maior
+-- vigente_desde que já começou. Os produtos existentes entram com o preço
+-- atual desde o cadastro.
+CREATE TABLE IF NOT EXISTS produto_precos (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	produto_id TEXT NOT NULL REFERENCES produtos (id),
+	preco REAL NOT NULL,
+	vigente_desde TEXT NOT NULL,
+	created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_produto_precos_vigencia ON produto_precos (produto_id, vigente_desde);
+INSERT INTO produto_precos (produto_id, preco, vigente_desde, created_at)
+SELECT id, preco, created_at, created_at FROM produtos;
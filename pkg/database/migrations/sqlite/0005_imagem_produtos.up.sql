@@ -0,0 +1,3 @@
+-- Imagem dos produtos: guarda só a chave do arquivo original no
+-- armazenamento; as miniaturas e as URLs derivam dela.
+ALTER TABLE produtos ADD COLUMN imagem TEXT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS categoria_traducoes;
+DROP TABLE IF EXISTS categorias;
@@ -0,0 +1,41 @@
+-- Categorias gerenciadas pela API. O código continua sendo o valor gravado em
+-- produtos.categoria. O SQLite não acrescenta chaves estrangeiras a tabelas
+-- existentes, então aqui a categoria dos produtos é garantida só pelo
+-- serviço. As quatro categorias que eram fixas entram com nome, ordem e
+-- tradução para o inglês; outros códigos já usados por produtos entram
+-- ativos, com o próprio código como nome.
+CREATE TABLE IF NOT EXISTS categorias (
+	codigo TEXT PRIMARY KEY,
+	nome TEXT NOT NULL,
+	icone TEXT NOT NULL DEFAULT '',
+	ordem INTEGER NOT NULL DEFAULT 0,
+	ativa INTEGER NOT NULL DEFAULT 1,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL,
+	versao INTEGER NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS idx_categorias_ordem ON categorias (ordem, codigo);
+
+CREATE TABLE IF NOT EXISTS categoria_traducoes (
+	categoria_codigo TEXT NOT NULL REFERENCES categorias (codigo) ON DELETE CASCADE,
+	idioma TEXT NOT NULL,
+	nome TEXT NOT NULL,
+	PRIMARY KEY (categoria_codigo, idioma)
+);
+
+INSERT INTO categorias (codigo, nome, ordem, created_at, updated_at) VALUES
+	('LANCHE', 'Lanches', 1, strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'), strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now')),
+	('ACOMPANHAMENTO', 'Acompanhamentos', 2, strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'), strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now')),
+	('BEBIDA', 'Bebidas', 3, strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'), strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now')),
+	('SOBREMESA', 'Sobremesas', 4, strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'), strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'));
+
+INSERT INTO categoria_traducoes (categoria_codigo, idioma, nome) VALUES
+	('LANCHE', 'en', 'Burgers'),
+	('ACOMPANHAMENTO', 'en', 'Sides'),
+	('BEBIDA', 'en', 'Drinks'),
+	('SOBREMESA', 'en', 'Desserts');
+
+INSERT INTO categorias (codigo, nome, ordem, created_at, updated_at)
+SELECT DISTINCT categoria, categoria, 100, strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'), strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now')
+FROM produtos
+WHERE categoria NOT IN ('LANCHE', 'ACOMPANHAMENTO', 'BEBIDA', 'SOBREMESA');
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// modosTLSPostgres traduz DB_TLS para o sslmode do PostgreSQL.
+var modosTLSPostgres = map[string]string{
+	TLSDesativado:  "disable",
+	TLSPreferido:   "prefer",
+	TLSObrigatorio: "require",
+	TLSVerificado:  "verify-full",
+}
+
+// dsnPostgres monta a URL de conexão do driver pgx. O PostgreSQL não tem
+// timeouts de leitura e escrita na conexão; as consultas são limitadas pelo
+// contexto de cada requisição.
+func dsnPostgres(c Conexao) string {
+	parametros := url.Values{}
+	parametros.Set("sslmode", modosTLSPostgres[c.TLS])
+	if c.TempoConexao > 0 {
+		// connect_timeout é em segundos inteiros; arredonda para cima.
+		parametros.Set("connect_timeout", strconv.Itoa(int(math.Ceil(c.TempoConexao.Seconds()))))
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.Usuario, c.Senha),
+		Host:     net.JoinHostPort(c.Host, c.Porta),
+		Path:     c.Banco,
+		RawQuery: parametros.Encode(),
+	}
+	return dsn.String()
+}
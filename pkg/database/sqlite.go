@@ -0,0 +1,19 @@
+package database
+
+import (
+	"net/url"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// dsnSQLite abre o arquivo do banco, criando-o se não existir. O modo WAL
+// permite leituras durante uma escrita e o busy_timeout faz as escritas
+// concorrentes aguardarem em vez de falharem com "database is locked".
+func dsnSQLite(c Conexao) string {
+	parametros := url.Values{}
+	parametros.Set("_foreign_keys", "on")
+	parametros.Set("_journal_mode", "WAL")
+	parametros.Set("_busy_timeout", "5000")
+	parametros.Set("_txlock", "immediate")
+	return "file:" + c.Caminho + "?" + parametros.Encode()
+}
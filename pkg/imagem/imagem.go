@@ -0,0 +1,128 @@
+// Package imagem identifica, valida e reduz as imagens enviadas pelos
+// clientes usando só a biblioteca padrão.
+package imagem
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"net/http"
+
+	// Registra o decodificador de GIF para Decodificar; só o primeiro
+	// quadro de um GIF animado é usado.
+	_ "image/gif"
+)
+
+var (
+	ErrFormatoNaoSuportado = errors.New("formato de imagem não suportado")
+	ErrDimensoesExcedidas  = errors.New("imagem com pixels demais")
+)
+
+const qualidadeJPEG = 85
+
+// extensoes são os formatos aceitos, pelo tipo detectado no conteúdo.
+var extensoes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// Detectar identifica o formato pelos primeiros bytes do conteúdo, e não
+// pelo nome do arquivo ou pelo Content-Type informado pelo cliente, e devolve
+// o tipo MIME e a extensão usada para gravar o arquivo.
+func Detectar(dados []byte) (tipo, extensao string, err error) {
+	tipo = http.DetectContentType(dados)
+	extensao, ok := extensoes[tipo]
+	if !ok {
+		return tipo, "", ErrFormatoNaoSuportado
+	}
+	return tipo, extensao, nil
+}
+
+// Decodificar lê a imagem conferindo antes, pelo cabeçalho, que ela não tem
+// mais que pixelsMaximos. Isso evita que um arquivo pequeno, mas com
+// dimensões enormes, esgote a memória ao ser decodificado.
+func Decodificar(dados []byte, pixelsMaximos int) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(dados))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > pixelsMaximos/config.Height {
+		return nil, ErrDimensoesExcedidas
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(dados))
+	return img, err
+}
+
+// Reduzir devolve uma cópia da imagem para cada largura, mantendo a
+// proporção. A redução faz a média dos pixels de origem que caem em cada
+// pixel de destino, o que evita o serrilhado da amostragem simples. Imagens
+// que já cabem na largura não são ampliadas.
+func Reduzir(img image.Image, larguras ...int) []image.Image {
+	limites := img.Bounds()
+	origem, ok := img.(*image.RGBA)
+	if !ok || limites.Min != (image.Point{}) {
+		origem = image.NewRGBA(image.Rect(0, 0, limites.Dx(), limites.Dy()))
+		draw.Draw(origem, origem.Bounds(), img, limites.Min, draw.Src)
+	}
+
+	reduzidas := make([]image.Image, len(larguras))
+	for i, largura := range larguras {
+		if largura >= origem.Bounds().Dx() {
+			reduzidas[i] = origem
+			continue
+		}
+		reduzidas[i] = reduzir(origem, largura)
+	}
+	return reduzidas
+}
+
+// reduzir trabalha sobre RGBA, cujas cores são pré-multiplicadas pelo alfa;
+// por isso a média simples dos canais não escurece as bordas transparentes.
+func reduzir(origem *image.RGBA, largura int) *image.RGBA {
+	larguraOrigem, alturaOrigem := origem.Bounds().Dx(), origem.Bounds().Dy()
+	altura := max(1, int(math.Round(float64(alturaOrigem)*float64(largura)/float64(larguraOrigem))))
+	destino := image.NewRGBA(image.Rect(0, 0, largura, altura))
+
+	for y := 0; y < altura; y++ {
+		y0 := y * alturaOrigem / altura
+		y1 := max(y0+1, (y+1)*alturaOrigem/altura)
+		for x := 0; x < largura; x++ {
+			x0 := x * larguraOrigem / largura
+			x1 := max(x0+1, (x+1)*larguraOrigem/largura)
+
+			var soma [4]uint64
+			for sy := y0; sy < y1; sy++ {
+				linha := origem.Pix[origem.PixOffset(x0, sy):origem.PixOffset(x1, sy)]
+				for p := 0; p < len(linha); p += 4 {
+					soma[0] += uint64(linha[p])
+					soma[1] += uint64(linha[p+1])
+					soma[2] += uint64(linha[p+2])
+					soma[3] += uint64(linha[p+3])
+				}
+			}
+
+			n := uint64((x1 - x0) * (y1 - y0))
+			pixel := destino.Pix[destino.PixOffset(x, y):]
+			for c := range soma {
+				pixel[c] = uint8((soma[c] + n/2) / n)
+			}
+		}
+	}
+	return destino
+}
+
+// Codificar grava img como JPEG quando extensao é ".jpg" e como PNG nos
+// demais casos.
+func Codificar(w io.Writer, img image.Image, extensao string) error {
+	if extensao == ".jpg" {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: qualidadeJPEG})
+	}
+	return png.Encode(w, img)
+}
@@ -0,0 +1,71 @@
+// Package logger configura o log/slog da aplicação e carrega o logger da
+// requisição pelo context.Context, para que serviços e repositórios registrem
+// eventos com os mesmos atributos (ex.: request_id) do handler que os chamou.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type chaveContexto struct{}
+
+// Novo cria um logger JSON no nível informado (debug, info, warn ou error).
+// Nível desconhecido usa info e é devolvido como false em valido.
+func Novo(saida io.Writer, nivel string) (logger *slog.Logger, valido bool) {
+	var level slog.Level
+	valido = level.UnmarshalText([]byte(nivel)) == nil
+	if !valido {
+		level = slog.LevelInfo
+	}
+
+	handler := slog.NewJSONHandler(saida, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: mascararDadosPessoais,
+	})
+	return slog.New(handler), valido
+}
+
+// NoContexto devolve um contexto que carrega o logger.
+func NoContexto(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, chaveContexto{}, logger)
+}
+
+// DoContexto devolve o logger guardado no contexto ou o logger padrão.
+func DoContexto(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(chaveContexto{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// mascararDadosPessoais oculta CPF e e-mail em qualquer atributo com essas
+// chaves, para que dados pessoais não cheguem ao agregador de logs.
+func mascararDadosPessoais(_ []string, a slog.Attr) slog.Attr {
+	switch strings.ToLower(a.Key) {
+	case "cpf":
+		a.Value = slog.StringValue(MascararCPF(a.Value.String()))
+	case "email":
+		a.Value = slog.StringValue(MascararEmail(a.Value.String()))
+	}
+	return a
+}
+
+// MascararCPF mantém apenas os dois últimos dígitos, ex.: *********25.
+func MascararCPF(cpf string) string {
+	if len(cpf) <= 2 {
+		return strings.Repeat("*", len(cpf))
+	}
+	return strings.Repeat("*", len(cpf)-2) + cpf[len(cpf)-2:]
+}
+
+// MascararEmail mantém a primeira letra e o domínio, ex.: a***@exemplo.com.
+func MascararEmail(email string) string {
+	usuario, dominio, ok := strings.Cut(email, "@")
+	if !ok || usuario == "" {
+		return "***"
+	}
+	return string([]rune(usuario)[:1]) + "***@" + dominio
+}
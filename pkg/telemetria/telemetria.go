@@ -0,0 +1,83 @@
+// Package telemetria configura o OpenTelemetry da aplicação: o provedor de
+// traces, a amostragem, o exportador e a propagação W3C (traceparent).
+package telemetria
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Opcoes configura o rastreamento. Sem Endpoint os spans vão para a saída
+// padrão, útil em desenvolvimento quando não há coletor.
+type Opcoes struct {
+	Habilitado bool
+	Servico    string
+	Versao     string
+	// Endpoint é a URL OTLP/HTTP do coletor (ex.: http://otel-collector:4318).
+	Endpoint string
+	// Amostragem é a fração de traces iniciados aqui que são gravados, de 0
+	// a 1. Traces que chegam de outro serviço seguem a decisão de quem os
+	// iniciou.
+	Amostragem float64
+}
+
+// Configurar registra o provedor global de traces e o propagador W3C. O
+// propagador é registrado mesmo com o rastreamento desabilitado, para que o
+// trace de quem chamou continue nos logs. A função devolvida descarrega os
+// spans pendentes e deve ser chamada no desligamento.
+func Configurar(ctx context.Context, o Opcoes) (encerrar func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !o.Habilitado {
+		return func(context.Context) error { return nil }, nil
+	}
+	if o.Amostragem < 0 || o.Amostragem > 1 {
+		return nil, fmt.Errorf("amostragem deve estar entre 0 e 1: %v", o.Amostragem)
+	}
+
+	exportador, err := novoExportador(ctx, o.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar exportador de traces: %w", err)
+	}
+
+	recurso, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(
+			semconv.ServiceName(o.Servico),
+			semconv.ServiceVersion(o.Versao),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao descrever o recurso: %w", err)
+	}
+
+	provedor := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exportador),
+		sdktrace.WithResource(recurso),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.Amostragem))),
+	)
+	otel.SetTracerProvider(provedor)
+
+	return provedor.Shutdown, nil
+}
+
+func novoExportador(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	if endpoint == "" {
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	}
+	return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+}